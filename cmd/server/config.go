@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+)
+
+// flagset holds the --set key=value config overrides.
+var flagset []string
+
+// configPrecedence documents the config sources, from the lowest to the highest precedence.
+var configPrecedence = []string{
+	"files in --conf",
+	"environment variables " + conf.EnvPrefix + "<KEY>, e.g. " + conf.EnvPrefix + "DATA_DATABASE_SOURCE",
+	"--set <key>=<value> flags, e.g. --set data.database.source=...",
+}
+
+// loadedConfig is the configuration loaded by loadConfig.
+type loadedConfig struct {
+	config.Config
+
+	// Bootstrap is the scanned configuration.
+	Bootstrap *conf.Bootstrap
+	// Overrides are the fields set by the environment variables and the --set flags.
+	Overrides []conf.Override
+	// Secrets are the secret references resolved in the configuration.
+	Secrets *conf.Secrets
+	// UnknownKeys are the keys of the files that are not configuration fields.
+	UnknownKeys []conf.UnknownKey
+}
+
+// loadConfig loads the configuration from the files in flagconf, overridden by the
+// GOTEMPLATE_ environment variables, overridden in turn by the --set flags.
+// The secret references in every value, such as ${env:DB_PASSWORD}, are then resolved,
+// and the result is checked against the validation rules of conf.proto.
+// The caller must close the returned config.
+func loadConfig() (*loadedConfig, error) {
+	return loadConfigResolving(true)
+}
+
+// loadConfigUnresolved is loadConfig leaving the secret references unresolved, for the commands
+// that connect to nothing and must not require the secrets to be available.
+func loadConfigUnresolved() (*loadedConfig, error) {
+	return loadConfigResolving(false)
+}
+
+func loadConfigResolving(resolveSecrets bool) (*loadedConfig, error) {
+	env, err := conf.NewEnvSource(conf.EnvPrefix)
+	if err != nil {
+		return nil, err
+	}
+	set, err := conf.NewSetSource(flagset)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := &conf.Secrets{}
+	resolve := secrets.Resolver()
+	if !resolveSecrets {
+		// the default resolver would read ${env:NAME} as the key env with the default NAME
+		resolve = func(map[string]interface{}) error { return nil }
+	}
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+			env,
+			set,
+		),
+		config.WithResolver(resolve),
+	)
+	if err := c.Load(); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := conf.Validate(&bc); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	unknown, err := conf.UnknownKeys(flagconf)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to check config keys: %w", err)
+	}
+
+	return &loadedConfig{
+		Config:      c,
+		Bootstrap:   &bc,
+		Overrides:   append(env.Overrides(), set.Overrides()...),
+		Secrets:     secrets,
+		UnknownKeys: unknown,
+	}, nil
+}
+
+// reloadDelay is how long watchConfig waits for the files to settle after a change,
+// since editors and tools may write a file in several steps.
+const reloadDelay = 500 * time.Millisecond
+
+// watchConfig reloads the configuration whenever the files in flagconf change, until stop is called.
+// Every source is loaded again, rather than merged into the running config, so that the environment
+// variables and the --set flags keep their precedence and keys removed from the files are unset.
+func watchConfig(reloader *conf.Reloader) (stop func(), err error) {
+	w, err := file.NewSource(flagconf).Watch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch config: %w", err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		for {
+			if _, err := w.Next(); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				// e.g. the temporary file of an editor, which is gone by now
+				continue
+			}
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	go func() {
+		for range changes {
+			time.Sleep(reloadDelay)
+			// the changes made while waiting are part of this reload
+			select {
+			case <-changes:
+			default:
+			}
+
+			c, err := loadConfig()
+			if err != nil {
+				reloader.Failed(err)
+				continue
+			}
+			c.Close()
+			reloader.Reload(c.Bootstrap)
+		}
+	}()
+
+	return func() { _ = w.Stop() }, nil
+}
+
+// printConfig writes bc to w in format, json or yaml, with every field including the unset ones.
+func printConfig(w io.Writer, bc *conf.Bootstrap, format string) error {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(bc)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	var out bytes.Buffer
+	switch format {
+	case "json":
+		if err := json.Indent(&out, b, "", "  "); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		out.WriteByte('\n')
+	case "yaml":
+		// JSON is YAML, decoding it into nodes keeps the order of the fields
+		var doc yaml.Node
+		if err := yaml.Unmarshal(b, &doc); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		blockStyle(&doc)
+		enc := yaml.NewEncoder(&out)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output %q, expected json or yaml", format)
+	}
+
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+// blockStyle switches node and its children from the JSON style, flow collections
+// and quoted strings, to the YAML block style, which only quotes strings when needed.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
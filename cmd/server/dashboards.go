@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	klog "github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	adminv1 "github.com/adam-xu-mantle/go-template/api/admin/v1"
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
+	"github.com/adam-xu-mantle/go-template/internal/server"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+	"github.com/adam-xu-mantle/go-template/internal/tracing"
+)
+
+var (
+	// dashboardsDir is the folder the dashboards command writes to.
+	dashboardsDir string
+	// dashboardsSLO are the objectives of the generated alert rules.
+	dashboardsSLO    metrics.SLO
+	latencyThreshold time.Duration
+)
+
+// dashboardsCmd represents the dashboards command
+var dashboardsCmd = &cobra.Command{
+	Use:   "dashboards",
+	Short: "Generate Grafana dashboards and Prometheus alert rules",
+	Long: `Generate a Grafana dashboard and Prometheus alert rules for every HTTP route and gRPC method
+the server registers with the configuration, into the --dir folder:
+  dashboard.json  the rate, errors and duration of the requests to every endpoint, of the
+                  database statements and of the config reloads, to import in Grafana
+  alerts.yaml     for every endpoint, an alert on its error ratio and on the burn rate of its
+                  latency SLO, to add to the rule_files of Prometheus
+The latency threshold must be one of metrics.request_buckets. The health checks, the admin
+service and the built-in gRPC and kratos services are left out.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateDashboards(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func generateDashboards() error {
+	// the servers are never started, so the secrets, e.g. of the database, are not needed
+	c, err := loadConfigUnresolved()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	bc := c.Bootstrap
+
+	endpoints, err := serverEndpoints(bc)
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := metrics.Dashboard(bc.Metrics, Name, endpoints)
+	if err != nil {
+		return err
+	}
+	dashboardsSLO.LatencyThreshold = latencyThreshold.Seconds()
+	alerts, err := metrics.AlertRules(bc.Metrics, Name, endpoints, dashboardsSLO)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dashboardsDir, 0o755); err != nil {
+		return err
+	}
+	for name, b := range map[string][]byte{"dashboard.json": append(dashboard, '\n'), "alerts.yaml": alerts} {
+		path := filepath.Join(dashboardsDir, name)
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	}
+	fmt.Fprintf(os.Stderr, "%d HTTP routes, %d gRPC methods\n", len(endpoints.Routes), len(endpoints.GRPCMethods))
+	return nil
+}
+
+// serverEndpoints returns the endpoints registered by the HTTP and gRPC servers configured by bc.
+// The servers are built without their dependencies, which their routes do not need, and never started.
+func serverEndpoints(bc *conf.Bootstrap) (metrics.Endpoints, error) {
+	logger := klog.NewStdLogger(io.Discard)
+	reg := prometheus.NewRegistry()
+	metricer, err := metrics.NewMetricer(bc.Metrics, reg)
+	if err != nil {
+		return metrics.Endpoints{}, err
+	}
+	reloadMetricer, err := metrics.NewReloadMetricer(bc.Metrics, reg)
+	if err != nil {
+		return metrics.Endpoints{}, err
+	}
+	reloader := conf.NewReloader(bc, reloadMetricer, logger)
+	registry := health.NewRegistry()
+	greeter := service.NewGreeterService(nil)
+
+	hs, err := server.NewHTTPServer(bc.Server, tracing.Service{Name: Name, Version: Version}, greeter, registry, reloader, metricer, logger)
+	if err != nil {
+		return metrics.Endpoints{}, err
+	}
+	admin := service.NewAdminService(nil, logger)
+	gs := server.NewGRPCServer(bc.Server, greeter, admin, server.NewAdminGate(bc.Metrics, reloader), registry, reloader, metricer, logger)
+
+	var e metrics.Endpoints
+	seen := make(map[string]bool)
+	for _, r := range hs.Routes() {
+		if seen[r.Path] || r.Path == "/healthz" || r.Path == "/readyz" {
+			continue
+		}
+		seen[r.Path] = true
+		e.Routes = append(e.Routes, r.Path)
+	}
+	for name, info := range gs.GetServiceInfo() {
+		// the health, reflection and channelz services of gRPC, the metadata service of kratos and the admin service
+		if strings.HasPrefix(name, "grpc.") || strings.HasPrefix(name, "kratos.api.") || name == adminv1.Admin_ServiceDesc.ServiceName {
+			continue
+		}
+		for _, m := range info.Methods {
+			e.GRPCMethods = append(e.GRPCMethods, "/"+name+"/"+m.Name)
+		}
+	}
+	sort.Strings(e.Routes)
+	sort.Strings(e.GRPCMethods)
+	return e, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+)
+
+func TestServerEndpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		graphql    *conf.Server_GraphQL
+		wantRoutes []string
+	}{
+		{
+			name:       "graphql",
+			wantRoutes: []string{"/graphql", "/helloworld/:name"},
+		},
+		{
+			name:       "graphql path and playground",
+			graphql:    &conf.Server_GraphQL{Path: "/query", Playground: true},
+			wantRoutes: []string{"/helloworld/:name", "/playground", "/query"},
+		},
+		{
+			name:       "graphql disabled",
+			graphql:    &conf.Server_GraphQL{Disable: true},
+			wantRoutes: []string{"/helloworld/:name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := &conf.Bootstrap{
+				Server: &conf.Server{
+					Http:    &conf.Server_HTTP{Addr: ":0"},
+					Grpc:    &conf.Server_GRPC{Addr: ":0"},
+					Graphql: tt.graphql,
+				},
+				// the admin service is left out even when it is served
+				Metrics: &conf.Metrics{Admin: true, BasicAuth: &conf.Metrics_BasicAuth{Username: "u", Password: "p"}},
+			}
+			e, err := serverEndpoints(bc)
+			if err != nil {
+				t.Fatalf("serverEndpoints() error = %v", err)
+			}
+			if !slices.Equal(e.Routes, tt.wantRoutes) {
+				t.Errorf("Routes = %v, want %v", e.Routes, tt.wantRoutes)
+			}
+			if want := []string{"/helloworld.v1.Greeter/SayHello"}; !slices.Equal(e.GRPCMethods, want) {
+				t.Errorf("GRPCMethods = %v, want %v", e.GRPCMethods, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigUnresolved(t *testing.T) {
+	dir := t.TempDir()
+	config := `
+server:
+  http:
+    addr: :8000
+  grpc:
+    addr: :9000
+data:
+  database:
+    driver: postgres
+    source: postgres://app:${env:DASHBOARDS_TEST_PASSWORD}@db/app
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	prev := flagconf
+	flagconf = dir
+	t.Cleanup(func() { flagconf = prev })
+
+	// the secret is not set
+	if _, err := loadConfig(); err == nil || !strings.Contains(err.Error(), "DASHBOARDS_TEST_PASSWORD is not set") {
+		t.Errorf("loadConfig() error = %v, want the unset secret", err)
+	}
+	c, err := loadConfigUnresolved()
+	if err != nil {
+		t.Fatalf("loadConfigUnresolved() error = %v", err)
+	}
+	defer c.Close()
+	if got, want := c.Bootstrap.GetData().GetDatabase().GetSource(), "postgres://app:${env:DASHBOARDS_TEST_PASSWORD}@db/app"; got != want {
+		t.Errorf("source = %q, want the unresolved %q", got, want)
+	}
+}
@@ -1,22 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-
 	"github.com/go-kratos/kratos/v2"
-	"github.com/go-kratos/kratos/v2/config"
-	"github.com/go-kratos/kratos/v2/config/file"
 	klog "github.com/go-kratos/kratos/v2/log"
+	ktracing "github.com/go-kratos/kratos/v2/middleware/tracing"
 
 	"github.com/adam-xu-mantle/go-template/internal/conf"
-	"github.com/adam-xu-mantle/go-template/internal/data"
+	"github.com/adam-xu-mantle/go-template/internal/health"
 	"github.com/adam-xu-mantle/go-template/internal/log"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
+	"github.com/adam-xu-mantle/go-template/internal/requestid"
 	"github.com/adam-xu-mantle/go-template/internal/server"
+	"github.com/adam-xu-mantle/go-template/internal/tracing"
 
 	"github.com/spf13/cobra"
 
@@ -31,14 +32,22 @@ var (
 	Version = "dev"
 	// flagconf is the config flag.
 	flagconf string
+	// configOutput is the format the config command writes the effective configuration in.
+	configOutput string
 
 	migration string
 
 	id, _ = os.Hostname()
 )
 
-func newApp(logger klog.Logger, gs *server.GRPCServer, hs *server.HTTPServer) *kratos.App {
-	return kratos.New(
+// tracingShutdownTimeout bounds the export of the last spans on exit.
+const tracingShutdownTimeout = 5 * time.Second
+
+// defaultGraceTimeout is used when conf.Server.Shutdown leaves grace_timeout unset.
+const defaultGraceTimeout = 30 * time.Second
+
+func newApp(c *conf.Server, logger klog.Logger, gs *server.GRPCServer, hs *server.HTTPServer, ms *server.MetricsServer, registry *health.Registry) *kratos.App {
+	opts := []kratos.Option{
 		kratos.ID(id),
 		kratos.Name(Name),
 		kratos.Version(Version),
@@ -47,8 +56,36 @@ func newApp(logger klog.Logger, gs *server.GRPCServer, hs *server.HTTPServer) *k
 		kratos.Server(
 			gs,
 			hs,
+			ms,
 		),
-	)
+	}
+	return kratos.New(append(opts, shutdownOptions(c, registry, logger)...)...)
+}
+
+// shutdownOptions returns the options of the shutdown of c. On stop, the instance fails the
+// readiness checks of registry and keeps serving for the pre-stop delay, then every server stops
+// accepting connections and drains its in-flight requests for up to the grace timeout, and closes
+// the remaining connections. The data resources are closed by the cleanup of wireApp once
+// the servers are stopped.
+func shutdownOptions(c *conf.Server, registry *health.Registry, logger klog.Logger) []kratos.Option {
+	preStopDelay := c.GetShutdown().GetPreStopDelay().AsDuration()
+	graceTimeout := defaultGraceTimeout
+	if t := c.GetShutdown().GetGraceTimeout(); t != nil {
+		graceTimeout = t.AsDuration()
+	}
+
+	return []kratos.Option{
+		kratos.StopTimeout(graceTimeout),
+		kratos.BeforeStop(func(context.Context) error {
+			registry.Shutdown()
+			if preStopDelay > 0 {
+				klog.NewHelper(logger).Infof("not ready, stopping the servers in %s", preStopDelay)
+				time.Sleep(preStopDelay)
+			}
+			klog.NewHelper(logger).Infof("stopping the servers, draining the requests for up to %s", graceTimeout)
+			return nil
+		}),
+	}
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -74,91 +111,118 @@ var versionCmd = &cobra.Command{
 // configCmd represents the config command
 var configCmd = &cobra.Command{
 	Use:   "config",
-	Short: "Validate configuration file",
-	Long:  `Validate the configuration file and print parsed settings.`,
+	Short: "Validate configuration",
+	Long: `Validate the configuration and print parsed settings.
+
+The configuration is loaded from the following sources, later ones taking precedence:
+  1. the YAML files in --conf
+  2. the environment variables GOTEMPLATE_<KEY>, where KEY is the config key in upper case
+     with dots replaced by underscores, e.g. GOTEMPLATE_DATA_DATABASE_SOURCE
+  3. the --set key=value flags, e.g. --set data.database.source=...
+Durations are written in seconds, e.g. 1.5s, and lists are comma separated.
+
+Any value may reference secrets, which are resolved when the configuration is loaded:
+  ${env:NAME}   the value of the environment variable NAME
+  ${file:PATH}  the content of the file at PATH, without its trailing newline
+A reference that cannot be resolved is an error. Resolved secrets are masked in the output.
+
+The configuration is checked against the validation rules of internal/conf/conf.proto,
+and keys of the files that are not configuration fields are reported with their line.
+With --output json|yaml, the effective configuration is written to stdout with secrets redacted.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		validateConfig()
 	},
 }
 
-// migrateCmd represents the migrate command
-var migrateCmd = &cobra.Command{
-	Use:   "migrate",
-	Short: "Run database migrations",
-	Long:  `Run database migrations to set up or update the database schema.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		runMigrations()
-	},
-}
-
 func init() {
 	// Add persistent flags to root command
 	rootCmd.PersistentFlags().StringVarP(&flagconf, "conf", "c", "./configs", "config path, eg: -conf config.yaml")
+	rootCmd.PersistentFlags().StringArrayVar(&flagset, "set", nil, "override a config value, can be repeated, e.g. --set server.http.addr=:8080")
 	rootCmd.PersistentFlags().StringVarP(&migration, "migration", "m", "./migrations", "run database migration in the given folder, e.g. -migration=./migrations")
+	configCmd.Flags().StringVarP(&configOutput, "output", "o", "", "write the effective configuration with secrets redacted, json or yaml")
+	migrateCreateCmd.Flags().StringVar(&migrationDialect, "dialect", "", "only use the new migration for this database dialect, e.g. --dialect=postgres")
+	dashboardsCmd.Flags().StringVar(&dashboardsDir, "dir", "./deploy/observability", "folder to write dashboard.json and alerts.yaml to")
+	dashboardsCmd.Flags().Float64Var(&dashboardsSLO.ErrorRatio, "error-ratio", 0.05, "ratio of failed requests to an endpoint that alerts")
+	dashboardsCmd.Flags().DurationVar(&latencyThreshold, "latency-threshold", 500*time.Millisecond, "duration requests should complete in, one of the request duration buckets")
+	dashboardsCmd.Flags().Float64Var(&dashboardsSLO.LatencyObjective, "latency-objective", 0.99, "ratio of requests that should complete in the latency threshold")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(dashboardsCmd)
+
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateGotoCmd)
+	migrateCmd.AddCommand(migrateCreateCmd)
 }
 
 func runServer() {
-	c := config.New(
-		config.WithSource(
-			file.NewSource(flagconf),
-			// env.NewSource(""), Load env variables from the environment
-		),
-	)
+	c, err := loadConfig()
+	if err != nil {
+		panic(err)
+	}
 	defer c.Close()
+	bc := c.Bootstrap
 
-	if err := c.Load(); err != nil {
+	level := log.NewLevel(bc.Log.GetLevel())
+	logger, err := log.NewLoggerWithLevel(bc.Log, level)
+	if err != nil {
 		panic(err)
 	}
+	klog.NewHelper(logger).Info("starting logger")
+	// the request and trace IDs are left out of the logs written outside a request, e.g. on shutdown
+	logger = klog.With(log.OmitEmpty(logger, "request_id", "trace.id", "span.id"),
+		"service.id", id,
+		"service.version", Version,
+		"request_id", requestid.Valuer(),
+		"trace.id", ktracing.TraceID(),
+		"span.id", ktracing.SpanID(),
+	)
+	klog.SetLogger(logger)
 
-	var bc conf.Bootstrap
-	if err := c.Scan(&bc); err != nil {
+	svc := tracing.Service{Name: Name, Version: Version, ID: id}
+	shutdownTracing, err := tracing.Setup(context.Background(), bc.Tracing, svc)
+	if err != nil {
 		panic(err)
 	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			klog.Errorf("failed to flush the spans: %v", err)
+		}
+	}()
 
-	if bc.Metrics != nil && !bc.Metrics.Disable {
-		go func() {
-			addr := ":8080"
-			if bc.Metrics.Addr != "" {
-				addr = bc.Metrics.Addr
-			}
-			http.Handle("/metrics", promhttp.Handler())
-
-			server := &http.Server{
-				Addr:         addr,
-				Handler:      nil, // uses DefaultServeMux
-				ReadTimeout:  60 * time.Second,
-				WriteTimeout: 60 * time.Second,
-				IdleTimeout:  60 * time.Second,
-			}
-
-			if err := server.ListenAndServe(); err != nil {
-				panic(err)
-			}
-		}()
+	for _, k := range c.UnknownKeys {
+		klog.Warnf("unknown config key %s", k)
 	}
 
-	logger := log.NewLogger(bc.Log)
-	_ = logger.Log(klog.LevelInfo, "msg", "starting logger")
-	logger = klog.With(logger,
-		"level", klog.LevelInfo,
-		"ts", klog.DefaultTimestamp,
-		"caller", klog.DefaultCaller,
-		"service.id", id,
-		"service.version", Version,
-	)
-	klog.SetLogger(logger)
+	registry := metrics.NewRegistry()
+	reloadMetricer, err := metrics.NewReloadMetricer(bc.Metrics, registry)
+	if err != nil {
+		panic(err)
+	}
+	reloader := conf.NewReloader(bc, reloadMetricer, logger)
+	reloader.Register(func(bc *conf.Bootstrap) error {
+		level.Set(bc.GetLog().GetLevel())
+		return nil
+	}, "log.level")
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
+	app, cleanup, err := wireApp(bc.Server, svc, bc.Data, bc.Metrics, reloader, level, registry, logger)
 	if err != nil {
 		panic(err)
 	}
 	defer cleanup()
 
+	stopWatch, err := watchConfig(reloader)
+	if err != nil {
+		panic(err)
+	}
+	defer stopWatch()
+
 	// start and wait for stop signal
 	if err := app.Run(); err != nil {
 		panic(err)
@@ -166,28 +230,70 @@ func runServer() {
 }
 
 func validateConfig() {
-	fmt.Printf("Validating configuration from: %s\n", flagconf)
+	// the effective configuration is written alone to stdout, so that it can be piped
+	w := os.Stdout
+	if configOutput != "" {
+		w = os.Stderr
+		if configOutput != "json" && configOutput != "yaml" {
+			fmt.Fprintf(os.Stderr, "Error: unsupported output %q, expected json or yaml\n", configOutput)
+			os.Exit(1)
+		}
+	}
 
-	c := config.New(
-		config.WithSource(
-			file.NewSource(flagconf),
-			// env.NewSource(""), Load env variables from the environment
-		),
-	)
-	defer c.Close()
+	fmt.Fprintf(w, "Validating configuration from: %s\n", flagconf)
 
-	if err := c.Load(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+	fmt.Fprintf(w, "Configuration sources, later ones take precedence:\n")
+	for i, source := range configPrecedence {
+		fmt.Fprintf(w, "  %d. %s\n", i+1, source)
+	}
+
+	c, err := loadConfig()
+	if err != nil {
+		var invalid *conf.ValidationError
+		if errors.As(err, &invalid) {
+			fmt.Fprintf(os.Stderr, "Error: invalid config:\n")
+			for _, v := range invalid.Violations {
+				fmt.Fprintf(os.Stderr, "  %s\n", v)
+			}
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	defer c.Close()
 
-	var bc conf.Bootstrap
-	if err := c.Scan(&bc); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing config: %v\n", err)
+	if len(c.UnknownKeys) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unknown config keys:\n")
+		for _, k := range c.UnknownKeys {
+			fmt.Fprintf(os.Stderr, "  %s\n", k)
+		}
 		os.Exit(1)
 	}
 
-	fmt.Println("Configuration is valid!")
+	fmt.Fprintln(w, "Configuration is valid!")
+
+	if len(c.Overrides) > 0 {
+		fmt.Fprintf(w, "Overrides:\n")
+		for _, o := range c.Overrides {
+			fmt.Fprintf(w, "  %s (%s)\n", o.Key, o.Origin)
+		}
+	}
+
+	if refs := c.Secrets.Refs(); len(refs) > 0 {
+		fmt.Fprintf(w, "Secret references:\n")
+		for _, ref := range refs {
+			fmt.Fprintf(w, "  %s: %s\n", ref.Key, ref.Value)
+		}
+	}
+
+	bc := conf.Redact(c.Bootstrap, c.Secrets)
+	if configOutput != "" {
+		if err := printConfig(os.Stdout, bc, configOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Print configuration summary
 	if bc.Server != nil {
@@ -204,6 +310,7 @@ func validateConfig() {
 		fmt.Printf("Data configuration:\n")
 		if bc.Data.Database != nil {
 			fmt.Printf("  Database driver: %s\n", bc.Data.Database.Driver)
+			fmt.Printf("  Database source: %s\n", bc.Data.Database.Source)
 		}
 		if bc.Data.Redis != nil {
 			fmt.Printf("  Redis: %s\n", bc.Data.Redis.Addr)
@@ -223,55 +330,11 @@ func validateConfig() {
 	}
 }
 
-func runMigrations() {
-	fmt.Println("Running database migrations...")
-
-	// Load configuration to get database connection
-	c := config.New(
-		config.WithSource(
-			file.NewSource(flagconf),
-			// env.NewSource(""), Load env variables from the environment
-		),
-	)
-	defer c.Close()
-
-	if err := c.Load(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
-	}
-
-	var bc conf.Bootstrap
-	if err := c.Scan(&bc); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing config: %v\n", err)
-		os.Exit(1)
-	}
-
-	if bc.Data == nil || bc.Data.Database == nil {
-		fmt.Fprintf(os.Stderr, "No database configuration found\n")
-		os.Exit(1)
-	}
-
-	fmt.Printf("Database driver: %s\n", bc.Data.Database.Driver)
-	fmt.Printf("Migration files location: ./migrations/\n")
-
-	logger := log.NewLogger(bc.Log)
-
-	data, _, err := data.NewData(bc.Data, logger)
-	if err != nil {
-		panic(err)
-	}
-
-	if err := data.ExecuteSQLMigration(migration); err != nil {
-		panic(err)
-	}
-
-}
-
 func main() {
-	fmt.Println("Starting go-template...")
+	fmt.Fprintln(os.Stderr, "Starting go-template...")
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println("Program completed successfully")
+	fmt.Fprintln(os.Stderr, "Program completed successfully")
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	ilog "github.com/adam-xu-mantle/go-template/internal/log"
+	"github.com/adam-xu-mantle/go-template/internal/server"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+
+	"github.com/go-kratos/kratos/v2"
+	klog "github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// noopReloadMetricer records nothing.
+type noopReloadMetricer struct{}
+
+func (*noopReloadMetricer) RecordReload(string) {}
+
+func TestShutdownOptions(t *testing.T) {
+	const (
+		preStopDelay = 200 * time.Millisecond
+		graceTimeout = 200 * time.Millisecond
+	)
+	logger := klog.NewStdLogger(io.Discard)
+	registry := health.NewRegistry()
+	sock := filepath.Join(t.TempDir(), "metrics.sock")
+	mc := &conf.Metrics{Addr: sock, Pprof: true}
+	reloader := conf.NewReloader(&conf.Bootstrap{Metrics: mc}, &noopReloadMetricer{}, logger)
+	admin := service.NewAdminService(ilog.NewLevel(conf.LogLevel_INFO), logger)
+	ms := server.NewMetricsServer(mc, prometheus.NewRegistry(), registry, admin, server.NewAdminGate(mc, reloader), reloader, logger)
+
+	c := &conf.Server{Shutdown: &conf.Server_Shutdown{
+		PreStopDelay: durationpb.New(preStopDelay),
+		GraceTimeout: durationpb.New(graceTimeout),
+	}}
+	app := kratos.New(append([]kratos.Option{kratos.Logger(logger), kratos.Server(ms)}, shutdownOptions(c, registry, logger)...)...)
+	ran := make(chan error, 1)
+	go func() { ran <- app.Run() }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DisableKeepAlives: true,
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", sock)
+		},
+	}}
+	readyz := func() (int, error) {
+		resp, err := client.Get("http://metrics/readyz")
+		if err != nil {
+			return 0, err
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+	code, err := readyz()
+	for deadline := time.Now().Add(5 * time.Second); err != nil && time.Now().Before(deadline); code, err = readyz() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if code != http.StatusOK {
+		t.Fatalf("/readyz = %d, %v before the stop, want %d", code, err, http.StatusOK)
+	}
+
+	// a CPU profile is in flight when the application stops, and outlasts the grace timeout
+	profiled := make(chan time.Time, 1)
+	go func() {
+		resp, err := client.Get("http://metrics/debug/pprof/profile?seconds=10")
+		if err == nil {
+			_ = resp.Body.Close()
+			t.Error("the profile completed, want its connection closed")
+		}
+		profiled <- time.Now()
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	stopped := time.Now()
+	go func() { _ = app.Stop() }()
+
+	// the instance fails the readiness checks at once, and keeps serving for the pre-stop delay
+	time.Sleep(preStopDelay / 4)
+	if code, err := readyz(); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz = %d, %v during the pre-stop delay, want %d", code, err, http.StatusServiceUnavailable)
+	}
+
+	// the server drains the profile for the grace timeout, then closes its connection
+	closed := (<-profiled).Sub(stopped)
+	if closed < preStopDelay+graceTimeout || closed > 5*time.Second {
+		t.Errorf("the profile was closed %s after the stop, want after the pre-stop delay %s and the grace timeout %s", closed, preStopDelay, graceTimeout)
+	}
+	if err := <-ran; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+	if _, err := readyz(); err == nil {
+		t.Error("/readyz was served after the stop")
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/data"
+	"github.com/adam-xu-mantle/go-template/internal/log"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Run database migrations",
+	Long:  `Run database migrations to set up or update the database schema. Without a subcommand all pending migrations are applied.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silenceRunErrors(cmd)
+		return runMigrateUp(0)
+	},
+}
+
+// migrateUpCmd represents the migrate up command
+var migrateUpCmd = &cobra.Command{
+	Use:   "up [N]",
+	Short: "Apply pending migrations",
+	Long:  `Apply the next N pending migrations, or all of them when N is omitted.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := parseMigrationCount(args, 0)
+		if err != nil {
+			return err
+		}
+		silenceRunErrors(cmd)
+		return runMigrateUp(n)
+	},
+}
+
+// migrateDownCmd represents the migrate down command
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Roll back applied migrations",
+	Long:  `Roll back the last N applied migrations, or only the latest one when N is omitted.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := parseMigrationCount(args, 1)
+		if err != nil {
+			return err
+		}
+		silenceRunErrors(cmd)
+		return runMigrateDown(n)
+	},
+}
+
+// migrateStatusCmd represents the migrate status command
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show migration status",
+	Long:  `Show every migration in the migrations folder and whether it has been applied.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silenceRunErrors(cmd)
+		return runMigrateStatus()
+	},
+}
+
+// migrateGotoCmd represents the migrate goto command
+var migrateGotoCmd = &cobra.Command{
+	Use:   "goto V",
+	Short: "Migrate to a specific version",
+	Long:  `Apply or roll back migrations until V is the latest applied version. Use 0 to roll back everything.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid migration version: %s", args[0])
+		}
+		silenceRunErrors(cmd)
+		return runMigrateGoto(version)
+	},
+}
+
+// migrateCreateCmd represents the migrate create command
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a new migration",
+	Long:  `Create empty up and down scripts for the next migration version in the migrations folder. Use --dialect to create scripts that only apply to one database dialect.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silenceRunErrors(cmd)
+		up, down, err := data.CreateMigration(migration, args[0], migrationDialect)
+		if err != nil {
+			return fmt.Errorf("creating migration: %w", err)
+		}
+		fmt.Printf("Created %s\n", up)
+		fmt.Printf("Created %s\n", down)
+		return nil
+	},
+}
+
+// migrationDialect is the dialect flag of the migrate create command.
+var migrationDialect string
+
+// parseMigrationCount parses the optional N argument of migrate up/down.
+func parseMigrationCount(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid migration count: %s", args[0])
+	}
+	return n, nil
+}
+
+// silenceRunErrors stops cobra from printing the usage and the error once the arguments of cmd
+// are valid: the error of running the migrations is printed by main alone.
+func silenceRunErrors(cmd *cobra.Command) {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+}
+
+// newMigrator loads the configuration and connects to the database. The returned cleanup closes
+// the connection and must be called, also when the migrations fail.
+func newMigrator() (*data.Migrator, func(), error) {
+	c, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Close()
+	bc := c.Bootstrap
+
+	if bc.Data == nil || bc.Data.Database == nil {
+		return nil, nil, errors.New("no database configuration found")
+	}
+
+	fmt.Printf("Database driver: %s\n", bc.Data.Database.Driver)
+	fmt.Printf("Migration files location: %s\n", migration)
+
+	logger, err := log.NewLogger(bc.Log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating logger: %w", err)
+	}
+
+	// migrations only need the database
+	d, cleanup, err := data.NewData(&conf.Data{Database: bc.Data.Database}, nil, nil, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	m := data.NewMigrator(d, migration, logger)
+	fmt.Printf("Migration dialect: %s\n", m.Dialect())
+
+	return m, cleanup, nil
+}
+
+func runMigrateUp(n int) error {
+	fmt.Println("Running database migrations...")
+
+	m, cleanup, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	count, err := m.Up(context.Background(), n)
+	if err != nil {
+		return fmt.Errorf("applying migrations: %w", err)
+	}
+	fmt.Printf("Applied %d migration(s)\n", count)
+	return nil
+}
+
+func runMigrateDown(n int) error {
+	fmt.Println("Rolling back database migrations...")
+
+	m, cleanup, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	count, err := m.Down(context.Background(), n)
+	if err != nil {
+		return fmt.Errorf("rolling back migrations: %w", err)
+	}
+	fmt.Printf("Rolled back %d migration(s)\n", count)
+	return nil
+}
+
+func runMigrateGoto(version int64) error {
+	fmt.Printf("Migrating database to version %d...\n", version)
+
+	m, cleanup, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := m.Goto(context.Background(), version); err != nil {
+		return fmt.Errorf("migrating to version %d: %w", version, err)
+	}
+	fmt.Printf("Database is at version %d\n", version)
+	return nil
+}
+
+func runMigrateStatus() error {
+	m, cleanup, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		return fmt.Errorf("reading migration status: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		status, appliedAt := "pending", "-"
+		if s.Applied {
+			status, appliedAt = "applied", s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+	}
+	return w.Flush()
+}
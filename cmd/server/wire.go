@@ -9,15 +9,24 @@ import (
 	"github.com/adam-xu-mantle/go-template/internal/biz"
 	"github.com/adam-xu-mantle/go-template/internal/conf"
 	"github.com/adam-xu-mantle/go-template/internal/data"
+	ilog "github.com/adam-xu-mantle/go-template/internal/log"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
 	"github.com/adam-xu-mantle/go-template/internal/server"
 	"github.com/adam-xu-mantle/go-template/internal/service"
+	"github.com/adam-xu-mantle/go-template/internal/tracing"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // wireApp init kratos application.
-func wireApp(*conf.Server, *conf.Data, log.Logger) (*kratos.App, func(), error) {
-	panic(wire.Build(server.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, newApp))
+func wireApp(*conf.Server, tracing.Service, *conf.Data, *conf.Metrics, *conf.Reloader, *ilog.Level, *prometheus.Registry, log.Logger) (*kratos.App, func(), error) {
+	panic(wire.Build(
+		server.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, metrics.ProviderSet,
+		wire.Bind(new(prometheus.Registerer), new(*prometheus.Registry)),
+		wire.Bind(new(prometheus.Gatherer), new(*prometheus.Registry)),
+		newApp,
+	))
 }
@@ -10,11 +10,15 @@ import (
 	"github.com/adam-xu-mantle/go-template/internal/biz"
 	"github.com/adam-xu-mantle/go-template/internal/conf"
 	"github.com/adam-xu-mantle/go-template/internal/data"
+	"github.com/adam-xu-mantle/go-template/internal/log"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
 	"github.com/adam-xu-mantle/go-template/internal/server"
 	"github.com/adam-xu-mantle/go-template/internal/service"
+	"github.com/adam-xu-mantle/go-template/internal/tracing"
 
 	"github.com/go-kratos/kratos/v2"
-	"github.com/go-kratos/kratos/v2/log"
+	log2 "github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
 
 	_ "go.uber.org/automaxprocs"
 )
@@ -22,17 +26,30 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
-	dataData, cleanup, err := data.NewData(confData, logger)
+func wireApp(confServer *conf.Server, tracingService tracing.Service, confData *conf.Data, confMetrics *conf.Metrics, reloader *conf.Reloader, level *log.Level, registry *prometheus.Registry, logger log2.Logger) (*kratos.App, func(), error) {
+	metricer, err := metrics.NewMetricer(confMetrics, registry)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataData, cleanup, err := data.NewData(confData, reloader, metricer, logger)
 	if err != nil {
 		return nil, nil, err
 	}
 	greeterRepo := data.NewGreeterRepo(dataData, logger)
-	greeterUsecase := biz.NewGreeterUsecase(greeterRepo, logger)
+	transaction := data.NewTransaction(dataData)
+	greeterUsecase := biz.NewGreeterUsecase(greeterRepo, transaction, logger)
 	greeterService := service.NewGreeterService(greeterUsecase)
-	grpcServer := server.NewGRPCServer(confServer, greeterService, logger)
-	httpServer := server.NewHTTPServer(confServer, greeterService, logger)
-	app := newApp(logger, grpcServer, httpServer)
+	adminService := service.NewAdminService(level, logger)
+	adminGate := server.NewAdminGate(confMetrics, reloader)
+	healthRegistry := data.NewHealthRegistry(dataData)
+	grpcServer := server.NewGRPCServer(confServer, greeterService, adminService, adminGate, healthRegistry, reloader, metricer, logger)
+	httpServer, err := server.NewHTTPServer(confServer, tracingService, greeterService, healthRegistry, reloader, metricer, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	metricsServer := server.NewMetricsServer(confMetrics, registry, healthRegistry, adminService, adminGate, reloader, logger)
+	app := newApp(confServer, logger, grpcServer, httpServer, metricsServer, healthRegistry)
 	return app, func() {
 		cleanup()
 	}, nil
@@ -1,6 +1,18 @@
 package biz
 
-import "github.com/google/wire"
+import (
+	"context"
+
+	"github.com/google/wire"
+)
 
 // ProviderSet is biz providers.
 var ProviderSet = wire.NewSet(NewGreeterUsecase)
+
+// Transaction runs usecase steps atomically.
+type Transaction interface {
+	// InTx runs fn in a transaction. Repos called with the ctx passed to fn take part in it.
+	// The transaction is committed when fn returns nil and rolled back when it returns an
+	// error or panics. Nested calls run in a savepoint of the outer transaction.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -2,6 +2,7 @@ package biz
 
 import (
 	"context"
+	"time"
 
 	v1 "github.com/adam-xu-mantle/go-template/api/helloworld/v1"
 
@@ -16,7 +17,10 @@ var (
 
 // Greeter is a Greeter model.
 type Greeter struct {
-	Hello string
+	ID        int64
+	Hello     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // GreeterRepo is a Greater repo.
@@ -31,12 +35,14 @@ type GreeterRepo interface {
 // GreeterUsecase is a Greeter usecase.
 type GreeterUsecase struct {
 	repo GreeterRepo
-	log  *log.Helper
+	// tx runs the usecases of several repo calls atomically, a single call needs none.
+	tx  Transaction
+	log *log.Helper
 }
 
 // NewGreeterUsecase new a Greeter usecase.
-func NewGreeterUsecase(repo GreeterRepo, logger log.Logger) *GreeterUsecase {
-	return &GreeterUsecase{repo: repo, log: log.NewHelper(logger)}
+func NewGreeterUsecase(repo GreeterRepo, tx Transaction, logger log.Logger) *GreeterUsecase {
+	return &GreeterUsecase{repo: repo, tx: tx, log: log.NewHelper(logger)}
 }
 
 // CreateGreeter creates a Greeter, and returns the new Greeter.
@@ -44,3 +50,30 @@ func (uc *GreeterUsecase) CreateGreeter(ctx context.Context, g *Greeter) (*Greet
 	uc.log.WithContext(ctx).Infof("CreateGreeter: %v", g.Hello)
 	return uc.repo.Save(ctx, g)
 }
+
+// RenameGreeters changes the hello of every Greeter saying from to to, and returns the renamed Greeters.
+// Either every Greeter is renamed or none is.
+func (uc *GreeterUsecase) RenameGreeters(ctx context.Context, from, to string) ([]*Greeter, error) {
+	uc.log.WithContext(ctx).Infof("RenameGreeters: %v to %v", from, to)
+	var renamed []*Greeter
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		gs, err := uc.repo.ListByHello(ctx, from)
+		if err != nil {
+			return err
+		}
+		renamed = make([]*Greeter, 0, len(gs))
+		for _, g := range gs {
+			g.Hello = to
+			g, err = uc.repo.Update(ctx, g)
+			if err != nil {
+				return err
+			}
+			renamed = append(renamed, g)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renamed, nil
+}
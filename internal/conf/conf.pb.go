@@ -7,6 +7,7 @@
 package conf
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
@@ -131,6 +132,7 @@ type Bootstrap struct {
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	Log           *Log                   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
 	Metrics       *Metrics               `protobuf:"bytes,4,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Tracing       *Tracing               `protobuf:"bytes,5,opt,name=tracing,proto3" json:"tracing,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -193,10 +195,32 @@ func (x *Bootstrap) GetMetrics() *Metrics {
 	return nil
 }
 
+func (x *Bootstrap) GetTracing() *Tracing {
+	if x != nil {
+		return x.Tracing
+	}
+	return nil
+}
+
 type Log struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         LogLevel               `protobuf:"varint,1,opt,name=level,proto3,enum=kratos.api.LogLevel" json:"level,omitempty"`
-	Format        FormatType             `protobuf:"varint,2,opt,name=format,proto3,enum=kratos.api.FormatType" json:"format,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Level  LogLevel               `protobuf:"varint,1,opt,name=level,proto3,enum=kratos.api.LogLevel" json:"level,omitempty"`
+	Format FormatType             `protobuf:"varint,2,opt,name=format,proto3,enum=kratos.api.FormatType" json:"format,omitempty"`
+	// Colors the levels of the CONSOLE format.
+	Color bool `protobuf:"varint,3,opt,name=color,proto3" json:"color,omitempty"`
+	// Outputs of the entries, defaults to stdout.
+	Outputs []*Log_Output `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// Samples the entries to bound the cost of logging under load, disabled when unset.
+	Sampling *Log_Sampling `protobuf:"bytes,5,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// Format of the timestamps: a Go time layout, or RFC3339 (the default), RFC3339NANO,
+	// ISO8601, EPOCH, EPOCH_MILLIS or EPOCH_NANOS.
+	TimeFormat string `protobuf:"bytes,6,opt,name=time_format,json=timeFormat,proto3" json:"time_format,omitempty"`
+	// Omits the file and line of the caller from the entries.
+	DisableCaller bool `protobuf:"varint,7,opt,name=disable_caller,json=disableCaller,proto3" json:"disable_caller,omitempty"`
+	// Adds stack traces to the entries at ERROR and above.
+	Stacktrace bool `protobuf:"varint,8,opt,name=stacktrace,proto3" json:"stacktrace,omitempty"`
+	// Masks the secrets in the entries, enabled by default.
+	Redaction     *Log_Redaction `protobuf:"bytes,9,opt,name=redaction,proto3" json:"redaction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -245,17 +269,158 @@ func (x *Log) GetFormat() FormatType {
 	return FormatType_JSON
 }
 
+func (x *Log) GetColor() bool {
+	if x != nil {
+		return x.Color
+	}
+	return false
+}
+
+func (x *Log) GetOutputs() []*Log_Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Log) GetSampling() *Log_Sampling {
+	if x != nil {
+		return x.Sampling
+	}
+	return nil
+}
+
+func (x *Log) GetTimeFormat() string {
+	if x != nil {
+		return x.TimeFormat
+	}
+	return ""
+}
+
+func (x *Log) GetDisableCaller() bool {
+	if x != nil {
+		return x.DisableCaller
+	}
+	return false
+}
+
+func (x *Log) GetStacktrace() bool {
+	if x != nil {
+		return x.Stacktrace
+	}
+	return false
+}
+
+func (x *Log) GetRedaction() *Log_Redaction {
+	if x != nil {
+		return x.Redaction
+	}
+	return nil
+}
+
+type Tracing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address of the OTLP gRPC endpoint the spans are exported to, e.g. localhost:4317.
+	// Tracing is disabled when it is empty.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// Connects to the endpoint without TLS.
+	Insecure bool `protobuf:"varint,2,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	// Ratio of the traces sampled, defaults to 1. Calls that are part of a trace are sampled as their parent.
+	SampleRatio *float64 `protobuf:"fixed64,3,opt,name=sample_ratio,json=sampleRatio,proto3,oneof" json:"sample_ratio,omitempty"`
+	// Timeout of each export of spans, defaults to 10s.
+	ExportTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=export_timeout,json=exportTimeout,proto3" json:"export_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tracing) Reset() {
+	*x = Tracing{}
+	mi := &file_conf_conf_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tracing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracing) ProtoMessage() {}
+
+func (x *Tracing) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracing.ProtoReflect.Descriptor instead.
+func (*Tracing) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Tracing) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Tracing) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *Tracing) GetSampleRatio() float64 {
+	if x != nil && x.SampleRatio != nil {
+		return *x.SampleRatio
+	}
+	return 0
+}
+
+func (x *Tracing) GetExportTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ExportTimeout
+	}
+	return nil
+}
+
 type Metrics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
-	Disable       bool                   `protobuf:"varint,3,opt,name=disable,proto3" json:"disable,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Listen address, host:port or the path of a unix socket, defaults to :8080.
+	Addr    string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Disable bool   `protobuf:"varint,3,opt,name=disable,proto3" json:"disable,omitempty"`
+	// Namespace of the metrics, defaults to "default".
+	Namespace string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Buckets in seconds of the HTTP and gRPC request durations, defaults to the Prometheus default buckets.
+	RequestBuckets []float64 `protobuf:"fixed64,5,rep,packed,name=request_buckets,json=requestBuckets,proto3" json:"request_buckets,omitempty"`
+	// Buckets in seconds of the database statement durations, defaults to 1ms up to 2.5s.
+	DbBuckets []float64 `protobuf:"fixed64,6,rep,packed,name=db_buckets,json=dbBuckets,proto3" json:"db_buckets,omitempty"`
+	// Maximum number of distinct routes in the HTTP metrics, defaults to 200.
+	// Requests to other routes are recorded under the route "overflow".
+	MaxRoutes int32 `protobuf:"varint,7,opt,name=max_routes,json=maxRoutes,proto3" json:"max_routes,omitempty"`
+	// Requires HTTP basic authentication on every route but the health checks.
+	BasicAuth *Metrics_BasicAuth `protobuf:"bytes,8,opt,name=basic_auth,json=basicAuth,proto3" json:"basic_auth,omitempty"`
+	// Serves HTTPS instead of HTTP.
+	Tls *Metrics_TLS `protobuf:"bytes,9,opt,name=tls,proto3" json:"tls,omitempty"`
+	// Serves the runtime profiles of net/http/pprof under /debug/pprof/.
+	Pprof bool `protobuf:"varint,10,opt,name=pprof,proto3" json:"pprof,omitempty"`
+	// Serves the admin API, e.g. to change the log level, under /admin/ and on the gRPC server.
+	// Requires basic_auth, whose credentials the gRPC calls pass in their authorization metadata.
+	Admin         bool `protobuf:"varint,11,opt,name=admin,proto3" json:"admin,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Metrics) Reset() {
 	*x = Metrics{}
-	mi := &file_conf_conf_proto_msgTypes[2]
+	mi := &file_conf_conf_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -267,7 +432,7 @@ func (x *Metrics) String() string {
 func (*Metrics) ProtoMessage() {}
 
 func (x *Metrics) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[2]
+	mi := &file_conf_conf_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +445,7 @@ func (x *Metrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
 func (*Metrics) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+	return file_conf_conf_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Metrics) GetAddr() string {
@@ -297,17 +462,75 @@ func (x *Metrics) GetDisable() bool {
 	return false
 }
 
+func (x *Metrics) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Metrics) GetRequestBuckets() []float64 {
+	if x != nil {
+		return x.RequestBuckets
+	}
+	return nil
+}
+
+func (x *Metrics) GetDbBuckets() []float64 {
+	if x != nil {
+		return x.DbBuckets
+	}
+	return nil
+}
+
+func (x *Metrics) GetMaxRoutes() int32 {
+	if x != nil {
+		return x.MaxRoutes
+	}
+	return 0
+}
+
+func (x *Metrics) GetBasicAuth() *Metrics_BasicAuth {
+	if x != nil {
+		return x.BasicAuth
+	}
+	return nil
+}
+
+func (x *Metrics) GetTls() *Metrics_TLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *Metrics) GetPprof() bool {
+	if x != nil {
+		return x.Pprof
+	}
+	return false
+}
+
+func (x *Metrics) GetAdmin() bool {
+	if x != nil {
+		return x.Admin
+	}
+	return false
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Graphql       *Server_GraphQL        `protobuf:"bytes,3,opt,name=graphql,proto3" json:"graphql,omitempty"`
+	Shutdown      *Server_Shutdown       `protobuf:"bytes,4,opt,name=shutdown,proto3" json:"shutdown,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Server) Reset() {
 	*x = Server{}
-	mi := &file_conf_conf_proto_msgTypes[3]
+	mi := &file_conf_conf_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -319,7 +542,7 @@ func (x *Server) String() string {
 func (*Server) ProtoMessage() {}
 
 func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[3]
+	mi := &file_conf_conf_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -332,7 +555,7 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server.ProtoReflect.Descriptor instead.
 func (*Server) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Server) GetHttp() *Server_HTTP {
@@ -349,17 +572,32 @@ func (x *Server) GetGrpc() *Server_GRPC {
 	return nil
 }
 
+func (x *Server) GetGraphql() *Server_GraphQL {
+	if x != nil {
+		return x.Graphql
+	}
+	return nil
+}
+
+func (x *Server) GetShutdown() *Server_Shutdown {
+	if x != nil {
+		return x.Shutdown
+	}
+	return nil
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
 	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	Cache         *Data_Cache            `protobuf:"bytes,3,opt,name=cache,proto3" json:"cache,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -371,7 +609,7 @@ func (x *Data) String() string {
 func (*Data) ProtoMessage() {}
 
 func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +622,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+	return file_conf_conf_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Data) GetDatabase() *Data_Database {
@@ -401,30 +639,48 @@ func (x *Data) GetRedis() *Data_Redis {
 	return nil
 }
 
-type Server_HTTP struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+func (x *Data) GetCache() *Data_Cache {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+type Log_Output struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// stdout, stderr or the path of a file, which is rotated.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Entries below min_level or above max_level are not written to the output,
+	// e.g. to write up to INFO to stdout and WARN and above to stderr.
+	MinLevel *LogLevel `protobuf:"varint,2,opt,name=min_level,json=minLevel,proto3,enum=kratos.api.LogLevel,oneof" json:"min_level,omitempty"`
+	MaxLevel *LogLevel `protobuf:"varint,3,opt,name=max_level,json=maxLevel,proto3,enum=kratos.api.LogLevel,oneof" json:"max_level,omitempty"`
+	// Size in megabytes at which a file is rotated, defaults to 100.
+	MaxSizeMb int32 `protobuf:"varint,4,opt,name=max_size_mb,json=maxSizeMb,proto3" json:"max_size_mb,omitempty"`
+	// Age after which rotated files are removed, rounded up to days. They are kept when unset.
+	MaxAge *durationpb.Duration `protobuf:"bytes,5,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Number of rotated files kept, all of them when 0.
+	MaxBackups int32 `protobuf:"varint,6,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"`
+	// Compresses the rotated files with gzip.
+	Compress      bool `protobuf:"varint,7,opt,name=compress,proto3" json:"compress,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server_HTTP) Reset() {
-	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+func (x *Log_Output) Reset() {
+	*x = Log_Output{}
+	mi := &file_conf_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_HTTP) String() string {
+func (x *Log_Output) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_HTTP) ProtoMessage() {}
+func (*Log_Output) ProtoMessage() {}
 
-func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+func (x *Log_Output) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,56 +691,86 @@ func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
-func (*Server_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use Log_Output.ProtoReflect.Descriptor instead.
+func (*Log_Output) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
 }
 
-func (x *Server_HTTP) GetNetwork() string {
+func (x *Log_Output) GetPath() string {
 	if x != nil {
-		return x.Network
+		return x.Path
 	}
 	return ""
 }
 
-func (x *Server_HTTP) GetAddr() string {
+func (x *Log_Output) GetMinLevel() LogLevel {
+	if x != nil && x.MinLevel != nil {
+		return *x.MinLevel
+	}
+	return LogLevel_INFO
+}
+
+func (x *Log_Output) GetMaxLevel() LogLevel {
+	if x != nil && x.MaxLevel != nil {
+		return *x.MaxLevel
+	}
+	return LogLevel_INFO
+}
+
+func (x *Log_Output) GetMaxSizeMb() int32 {
 	if x != nil {
-		return x.Addr
+		return x.MaxSizeMb
 	}
-	return ""
+	return 0
 }
 
-func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
+func (x *Log_Output) GetMaxAge() *durationpb.Duration {
 	if x != nil {
-		return x.Timeout
+		return x.MaxAge
 	}
 	return nil
 }
 
-type Server_GRPC struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+func (x *Log_Output) GetMaxBackups() int32 {
+	if x != nil {
+		return x.MaxBackups
+	}
+	return 0
+}
+
+func (x *Log_Output) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
+type Log_Sampling struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every tick, the first `initial` entries with the same level and message are logged,
+	// then every `thereafter`th one. Tick defaults to 1s, initial to 100 and thereafter to 100.
+	Tick          *durationpb.Duration `protobuf:"bytes,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Initial       int32                `protobuf:"varint,2,opt,name=initial,proto3" json:"initial,omitempty"`
+	Thereafter    int32                `protobuf:"varint,3,opt,name=thereafter,proto3" json:"thereafter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Server_GRPC) Reset() {
-	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *Log_Sampling) Reset() {
+	*x = Log_Sampling{}
+	mi := &file_conf_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_GRPC) String() string {
+func (x *Log_Sampling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_GRPC) ProtoMessage() {}
+func (*Log_Sampling) ProtoMessage() {}
 
-func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *Log_Sampling) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,55 +781,63 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
-func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use Log_Sampling.ProtoReflect.Descriptor instead.
+func (*Log_Sampling) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
 }
 
-func (x *Server_GRPC) GetNetwork() string {
+func (x *Log_Sampling) GetTick() *durationpb.Duration {
 	if x != nil {
-		return x.Network
+		return x.Tick
 	}
-	return ""
+	return nil
 }
 
-func (x *Server_GRPC) GetAddr() string {
+func (x *Log_Sampling) GetInitial() int32 {
 	if x != nil {
-		return x.Addr
+		return x.Initial
 	}
-	return ""
+	return 0
 }
 
-func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+func (x *Log_Sampling) GetThereafter() int32 {
 	if x != nil {
-		return x.Timeout
+		return x.Thereafter
 	}
-	return nil
-}
-
-type Data_Database struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
-	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	return 0
+}
+
+type Log_Redaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Disables the redaction of the entries.
+	Disable bool `protobuf:"varint,1,opt,name=disable,proto3" json:"disable,omitempty"`
+	// Keys of the secrets, matched case-insensitively within log keys, query parameters,
+	// key=value pairs and SQL columns, whose values are masked. They are added to the defaults:
+	// password, passwd, pwd, secret, token, authorization, cookie, api_key, apikey and private_key.
+	Keys []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Regular expressions of the secrets masked wherever they appear in the values, only their
+	// capture groups when they have any. They are added to the defaults, which mask emails,
+	// bearer tokens, JWTs and the passwords of URL DSNs.
+	Patterns      []string `protobuf:"bytes,3,rep,name=patterns,proto3" json:"patterns,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Database) Reset() {
-	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+func (x *Log_Redaction) Reset() {
+	*x = Log_Redaction{}
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Database) String() string {
+func (x *Log_Redaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Database) ProtoMessage() {}
+func (*Log_Redaction) ProtoMessage() {}
 
-func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+func (x *Log_Redaction) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,50 +848,587 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
-func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+// Deprecated: Use Log_Redaction.ProtoReflect.Descriptor instead.
+func (*Log_Redaction) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 2}
 }
 
-func (x *Data_Database) GetDriver() string {
+func (x *Log_Redaction) GetDisable() bool {
 	if x != nil {
-		return x.Driver
+		return x.Disable
 	}
-	return ""
+	return false
 }
 
-func (x *Data_Database) GetSource() string {
+func (x *Log_Redaction) GetKeys() []string {
 	if x != nil {
-		return x.Source
+		return x.Keys
 	}
-	return ""
+	return nil
 }
 
-type Data_Redis struct {
+func (x *Log_Redaction) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+type Metrics_BasicAuth struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
-	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Redis) Reset() {
-	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+func (x *Metrics_BasicAuth) Reset() {
+	*x = Metrics_BasicAuth{}
+	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Redis) String() string {
+func (x *Metrics_BasicAuth) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Redis) ProtoMessage() {}
+func (*Metrics_BasicAuth) ProtoMessage() {}
+
+func (x *Metrics_BasicAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics_BasicAuth.ProtoReflect.Descriptor instead.
+func (*Metrics_BasicAuth) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Metrics_BasicAuth) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Metrics_BasicAuth) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type Metrics_TLS struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the PEM certificate, including the intermediates.
+	CertFile string `protobuf:"bytes,1,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	// Path of the PEM private key.
+	KeyFile       string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metrics_TLS) Reset() {
+	*x = Metrics_TLS{}
+	mi := &file_conf_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics_TLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics_TLS) ProtoMessage() {}
+
+func (x *Metrics_TLS) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics_TLS.ProtoReflect.Descriptor instead.
+func (*Metrics_TLS) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Metrics_TLS) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *Metrics_TLS) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+type Server_HTTP struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Network string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// Listen address, host:port or the path of a unix socket.
+	Addr          string               `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_HTTP) Reset() {
+	*x = Server_HTTP{}
+	mi := &file_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_HTTP) ProtoMessage() {}
+
+func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
+func (*Server_HTTP) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Server_HTTP) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server_HTTP) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Server_GRPC struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Network string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// Listen address, host:port or the path of a unix socket.
+	Addr          string               `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_GRPC) Reset() {
+	*x = Server_GRPC{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_GRPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_GRPC) ProtoMessage() {}
+
+func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
+func (*Server_GRPC) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Server_GRPC) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Server_GRPC) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type Server_GraphQL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Disables the GraphQL endpoint.
+	Disable bool `protobuf:"varint,1,opt,name=disable,proto3" json:"disable,omitempty"`
+	// Path of the GraphQL endpoint, defaults to /graphql.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Serves the GraphQL playground.
+	Playground bool `protobuf:"varint,3,opt,name=playground,proto3" json:"playground,omitempty"`
+	// Path of the GraphQL playground, defaults to /playground.
+	PlaygroundPath string `protobuf:"bytes,4,opt,name=playground_path,json=playgroundPath,proto3" json:"playground_path,omitempty"`
+	// Allows introspection queries.
+	Introspection bool `protobuf:"varint,5,opt,name=introspection,proto3" json:"introspection,omitempty"`
+	// Maximum complexity of a query, defaults to 200.
+	ComplexityLimit int32 `protobuf:"varint,6,opt,name=complexity_limit,json=complexityLimit,proto3" json:"complexity_limit,omitempty"`
+	// Maximum depth of a query, defaults to 10.
+	DepthLimit int32 `protobuf:"varint,7,opt,name=depth_limit,json=depthLimit,proto3" json:"depth_limit,omitempty"`
+	// Number of automatic persisted queries kept in memory, defaults to 100.
+	ApqCacheSize  int32 `protobuf:"varint,8,opt,name=apq_cache_size,json=apqCacheSize,proto3" json:"apq_cache_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_GraphQL) Reset() {
+	*x = Server_GraphQL{}
+	mi := &file_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_GraphQL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_GraphQL) ProtoMessage() {}
+
+func (x *Server_GraphQL) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_GraphQL.ProtoReflect.Descriptor instead.
+func (*Server_GraphQL) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *Server_GraphQL) GetDisable() bool {
+	if x != nil {
+		return x.Disable
+	}
+	return false
+}
+
+func (x *Server_GraphQL) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Server_GraphQL) GetPlayground() bool {
+	if x != nil {
+		return x.Playground
+	}
+	return false
+}
+
+func (x *Server_GraphQL) GetPlaygroundPath() string {
+	if x != nil {
+		return x.PlaygroundPath
+	}
+	return ""
+}
+
+func (x *Server_GraphQL) GetIntrospection() bool {
+	if x != nil {
+		return x.Introspection
+	}
+	return false
+}
+
+func (x *Server_GraphQL) GetComplexityLimit() int32 {
+	if x != nil {
+		return x.ComplexityLimit
+	}
+	return 0
+}
+
+func (x *Server_GraphQL) GetDepthLimit() int32 {
+	if x != nil {
+		return x.DepthLimit
+	}
+	return 0
+}
+
+func (x *Server_GraphQL) GetApqCacheSize() int32 {
+	if x != nil {
+		return x.ApqCacheSize
+	}
+	return 0
+}
+
+type Server_Shutdown struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time the servers keep serving once the readiness checks fail, for load balancers
+	// to stop sending requests, defaults to none.
+	PreStopDelay *durationpb.Duration `protobuf:"bytes,1,opt,name=pre_stop_delay,json=preStopDelay,proto3" json:"pre_stop_delay,omitempty"`
+	// Time the in-flight requests have to complete once the servers stop accepting connections,
+	// after which the remaining connections are closed, defaults to 30s.
+	GraceTimeout  *durationpb.Duration `protobuf:"bytes,2,opt,name=grace_timeout,json=graceTimeout,proto3" json:"grace_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Shutdown) Reset() {
+	*x = Server_Shutdown{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Shutdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Shutdown) ProtoMessage() {}
+
+func (x *Server_Shutdown) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Shutdown.ProtoReflect.Descriptor instead.
+func (*Server_Shutdown) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 3}
+}
+
+func (x *Server_Shutdown) GetPreStopDelay() *durationpb.Duration {
+	if x != nil {
+		return x.PreStopDelay
+	}
+	return nil
+}
+
+func (x *Server_Shutdown) GetGraceTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.GraceTimeout
+	}
+	return nil
+}
+
+type Data_Database struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Driver string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	// DSN of the primary database.
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// Maximum number of open connections, defaults to 200.
+	MaxOpenConns int32 `protobuf:"varint,3,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	// Maximum number of idle connections, defaults to 10.
+	MaxIdleConns int32 `protobuf:"varint,4,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	// Maximum amount of time a connection may be reused, defaults to 1h.
+	ConnMaxLifetime *durationpb.Duration `protobuf:"bytes,5,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
+	// Maximum amount of time a connection may be idle, defaults to 5m.
+	ConnMaxIdleTime *durationpb.Duration `protobuf:"bytes,6,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
+	// Timeout of each attempt to connect on startup, defaults to 5s.
+	ConnectTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=connect_timeout,json=connectTimeout,proto3" json:"connect_timeout,omitempty"`
+	// Number of times connecting on startup is retried, defaults to 0.
+	ConnectRetries int32 `protobuf:"varint,8,opt,name=connect_retries,json=connectRetries,proto3" json:"connect_retries,omitempty"`
+	// Delay before the first retry, doubled after every attempt, defaults to 1s.
+	ConnectRetryBackoff *durationpb.Duration `protobuf:"bytes,9,opt,name=connect_retry_backoff,json=connectRetryBackoff,proto3" json:"connect_retry_backoff,omitempty"`
+	// DSNs of read replicas, reads are spread over the healthy ones round-robin.
+	Replicas []string `protobuf:"bytes,10,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	// Interval between read replica health checks, defaults to 5s.
+	ReplicaHealthCheckInterval *durationpb.Duration `protobuf:"bytes,11,opt,name=replica_health_check_interval,json=replicaHealthCheckInterval,proto3" json:"replica_health_check_interval,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *Data_Database) Reset() {
+	*x = Data_Database{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database) ProtoMessage() {}
+
+func (x *Data_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
+func (*Data_Database) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *Data_Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Database) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Data_Database) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetConnectTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectTimeout
+	}
+	return nil
+}
+
+func (x *Data_Database) GetConnectRetries() int32 {
+	if x != nil {
+		return x.ConnectRetries
+	}
+	return 0
+}
+
+func (x *Data_Database) GetConnectRetryBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectRetryBackoff
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicas() []string {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicaHealthCheckInterval() *durationpb.Duration {
+	if x != nil {
+		return x.ReplicaHealthCheckInterval
+	}
+	return nil
+}
+
+type Data_Redis struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Network      string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr         string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	ReadTimeout  *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	WriteTimeout *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	Password     string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	Db           int32                  `protobuf:"varint,6,opt,name=db,proto3" json:"db,omitempty"`
+	// Timeout of connecting to Redis, defaults to 5s.
+	DialTimeout   *durationpb.Duration `protobuf:"bytes,7,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Redis) Reset() {
+	*x = Data_Redis{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +1441,7 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
 func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4, 1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{5, 1}
 }
 
 func (x *Data_Redis) GetNetwork() string {
@@ -641,45 +1472,223 @@ func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *Data_Redis) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetDb() int32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetDialTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DialTimeout
+	}
+	return nil
+}
+
+type Data_Cache struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Disables the Redis cache in front of the repos.
+	Disable bool `protobuf:"varint,1,opt,name=disable,proto3" json:"disable,omitempty"`
+	// TTL of entries cached by id, defaults to 5m.
+	ItemTtl *durationpb.Duration `protobuf:"bytes,2,opt,name=item_ttl,json=itemTtl,proto3" json:"item_ttl,omitempty"`
+	// TTL of cached lists, defaults to 1m.
+	ListTtl       *durationpb.Duration `protobuf:"bytes,3,opt,name=list_ttl,json=listTtl,proto3" json:"list_ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Cache) Reset() {
+	*x = Data_Cache{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Cache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Cache) ProtoMessage() {}
+
+func (x *Data_Cache) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Cache.ProtoReflect.Descriptor instead.
+func (*Data_Cache) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{5, 2}
+}
+
+func (x *Data_Cache) GetDisable() bool {
+	if x != nil {
+		return x.Disable
+	}
+	return false
+}
+
+func (x *Data_Cache) GetItemTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ItemTtl
+	}
+	return nil
+}
+
+func (x *Data_Cache) GetListTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ListTtl
+	}
+	return nil
+}
+
 var File_conf_conf_proto protoreflect.FileDescriptor
 
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xaf\x01\n" +
-	"\tBootstrap\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
-	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12!\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x17validate/validate.proto\"\xf2\x01\n" +
+	"\tBootstrap\x124\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x06server\x12.\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04data\x12!\n" +
 	"\x03log\x18\x03 \x01(\v2\x0f.kratos.api.LogR\x03log\x12-\n" +
-	"\ametrics\x18\x04 \x01(\v2\x13.kratos.api.MetricsR\ametrics\"a\n" +
-	"\x03Log\x12*\n" +
-	"\x05level\x18\x01 \x01(\x0e2\x14.kratos.api.LogLevelR\x05level\x12.\n" +
-	"\x06format\x18\x02 \x01(\x0e2\x16.kratos.api.FormatTypeR\x06format\"7\n" +
-	"\aMetrics\x12\x12\n" +
-	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x18\n" +
-	"\adisable\x18\x03 \x01(\bR\adisable\"\xb8\x02\n" +
+	"\ametrics\x18\x04 \x01(\v2\x13.kratos.api.MetricsR\ametrics\x12-\n" +
+	"\atracing\x18\x05 \x01(\v2\x13.kratos.api.TracingR\atracing\"\x8e\b\n" +
+	"\x03Log\x124\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x14.kratos.api.LogLevelB\b\xfaB\x05\x82\x01\x02\x10\x01R\x05level\x128\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x16.kratos.api.FormatTypeB\b\xfaB\x05\x82\x01\x02\x10\x01R\x06format\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\bR\x05color\x120\n" +
+	"\aoutputs\x18\x04 \x03(\v2\x16.kratos.api.Log.OutputR\aoutputs\x124\n" +
+	"\bsampling\x18\x05 \x01(\v2\x18.kratos.api.Log.SamplingR\bsampling\x12\x1f\n" +
+	"\vtime_format\x18\x06 \x01(\tR\n" +
+	"timeFormat\x12%\n" +
+	"\x0edisable_caller\x18\a \x01(\bR\rdisableCaller\x12\x1e\n" +
+	"\n" +
+	"stacktrace\x18\b \x01(\bR\n" +
+	"stacktrace\x127\n" +
+	"\tredaction\x18\t \x01(\v2\x19.kratos.api.Log.RedactionR\tredaction\x1a\xf2\x02\n" +
+	"\x06Output\x12\x1b\n" +
+	"\x04path\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\x04path\x12@\n" +
+	"\tmin_level\x18\x02 \x01(\x0e2\x14.kratos.api.LogLevelB\b\xfaB\x05\x82\x01\x02\x10\x01H\x00R\bminLevel\x88\x01\x01\x12@\n" +
+	"\tmax_level\x18\x03 \x01(\x0e2\x14.kratos.api.LogLevelB\b\xfaB\x05\x82\x01\x02\x10\x01H\x01R\bmaxLevel\x88\x01\x01\x12'\n" +
+	"\vmax_size_mb\x18\x04 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\tmaxSizeMb\x12<\n" +
+	"\amax_age\x18\x05 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x06maxAge\x12(\n" +
+	"\vmax_backups\x18\x06 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\n" +
+	"maxBackups\x12\x1a\n" +
+	"\bcompress\x18\a \x01(\bR\bcompressB\f\n" +
+	"\n" +
+	"_min_levelB\f\n" +
+	"\n" +
+	"_max_level\x1a\x8f\x01\n" +
+	"\bSampling\x127\n" +
+	"\x04tick\x18\x01 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x04tick\x12!\n" +
+	"\ainitial\x18\x02 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\ainitial\x12'\n" +
+	"\n" +
+	"thereafter\x18\x03 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\n" +
+	"thereafter\x1aq\n" +
+	"\tRedaction\x12\x18\n" +
+	"\adisable\x18\x01 \x01(\bR\adisable\x12 \n" +
+	"\x04keys\x18\x02 \x03(\tB\f\xfaB\t\x92\x01\x06\"\x04r\x02\x10\x01R\x04keys\x12(\n" +
+	"\bpatterns\x18\x03 \x03(\tB\f\xfaB\t\x92\x01\x06\"\x04r\x02\x10\x01R\bpatterns\"\x9c\x02\n" +
+	"\aTracing\x12W\n" +
+	"\bendpoint\x18\x01 \x01(\tB;\xfaB8r621^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$\xd0\x01\x01R\bendpoint\x12\x1a\n" +
+	"\binsecure\x18\x02 \x01(\bR\binsecure\x12?\n" +
+	"\fsample_ratio\x18\x03 \x01(\x01B\x17\xfaB\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00H\x00R\vsampleRatio\x88\x01\x01\x12J\n" +
+	"\x0eexport_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\rexportTimeoutB\x0f\n" +
+	"\r_sample_ratio\"\x91\x05\n" +
+	"\aMetrics\x12O\n" +
+	"\x04addr\x18\x01 \x01(\tB;\xfaB8r621^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$\xd0\x01\x01R\x04addr\x12\x18\n" +
+	"\adisable\x18\x03 \x01(\bR\adisable\x12@\n" +
+	"\tnamespace\x18\x04 \x01(\tB\"\xfaB\x1fr\x1d2\x18^[a-zA-Z_][a-zA-Z0-9_]*$\xd0\x01\x01R\tnamespace\x12>\n" +
+	"\x0frequest_buckets\x18\x05 \x03(\x01B\x15\xfaB\x12\x92\x01\x0f\x18\x01\"\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\x0erequestBuckets\x124\n" +
+	"\n" +
+	"db_buckets\x18\x06 \x03(\x01B\x15\xfaB\x12\x92\x01\x0f\x18\x01\"\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\tdbBuckets\x12&\n" +
+	"\n" +
+	"max_routes\x18\a \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\tmaxRoutes\x12<\n" +
+	"\n" +
+	"basic_auth\x18\b \x01(\v2\x1d.kratos.api.Metrics.BasicAuthR\tbasicAuth\x12)\n" +
+	"\x03tls\x18\t \x01(\v2\x17.kratos.api.Metrics.TLSR\x03tls\x12\x14\n" +
+	"\x05pprof\x18\n" +
+	" \x01(\bR\x05pprof\x12\x14\n" +
+	"\x05admin\x18\v \x01(\bR\x05admin\x1aU\n" +
+	"\tBasicAuth\x12#\n" +
+	"\busername\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\busername\x12#\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\bpassword\x1aO\n" +
+	"\x03TLS\x12$\n" +
+	"\tcert_file\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\bcertFile\x12\"\n" +
+	"\bkey_file\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\akeyFile\"\xf5\b\n" +
 	"\x06Server\x12+\n" +
-	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
-	"\x04HTTP\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1ai\n" +
-	"\x04GRPC\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xdd\x02\n" +
-	"\x04Data\x125\n" +
-	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
-	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
-	"\bDatabase\x12\x16\n" +
-	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
-	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xb3\x01\n" +
-	"\x05Redis\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
-	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout*H\n" +
+	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x125\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04grpc\x124\n" +
+	"\agraphql\x18\x03 \x01(\v2\x1a.kratos.api.Server.GraphQLR\agraphql\x127\n" +
+	"\bshutdown\x18\x04 \x01(\v2\x1b.kratos.api.Server.ShutdownR\bshutdown\x1a\xd1\x01\n" +
+	"\x04HTTP\x129\n" +
+	"\anetwork\x18\x01 \x01(\tB\x1f\xfaB\x1cr\x1aR\x03tcpR\x04tcp4R\x04tcp6R\x04unix\xd0\x01\x01R\anetwork\x12O\n" +
+	"\x04addr\x18\x02 \x01(\tB;\xfaB8r621^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$\xd0\x01\x01R\x04addr\x12=\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\atimeout\x1a\xd1\x01\n" +
+	"\x04GRPC\x129\n" +
+	"\anetwork\x18\x01 \x01(\tB\x1f\xfaB\x1cr\x1aR\x03tcpR\x04tcp4R\x04tcp6R\x04unix\xd0\x01\x01R\anetwork\x12O\n" +
+	"\x04addr\x18\x02 \x01(\tB;\xfaB8r621^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$\xd0\x01\x01R\x04addr\x12=\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\atimeout\x1a\xcd\x02\n" +
+	"\aGraphQL\x12\x18\n" +
+	"\adisable\x18\x01 \x01(\bR\adisable\x12\x1f\n" +
+	"\x04path\x18\x02 \x01(\tB\v\xfaB\br\x06:\x01/\xd0\x01\x01R\x04path\x12\x1e\n" +
+	"\n" +
+	"playground\x18\x03 \x01(\bR\n" +
+	"playground\x124\n" +
+	"\x0fplayground_path\x18\x04 \x01(\tB\v\xfaB\br\x06:\x01/\xd0\x01\x01R\x0eplaygroundPath\x12$\n" +
+	"\rintrospection\x18\x05 \x01(\bR\rintrospection\x122\n" +
+	"\x10complexity_limit\x18\x06 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\x0fcomplexityLimit\x12(\n" +
+	"\vdepth_limit\x18\a \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\n" +
+	"depthLimit\x12-\n" +
+	"\x0eapq_cache_size\x18\b \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\fapqCacheSize\x1a\x9f\x01\n" +
+	"\bShutdown\x12I\n" +
+	"\x0epre_stop_delay\x18\x01 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x022\x00R\fpreStopDelay\x12H\n" +
+	"\rgrace_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\fgraceTimeout\"\xe5\v\n" +
+	"\x04Data\x12?\n" +
+	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseB\b\xfaB\x05\x8a\x01\x02\x10\x01R\bdatabase\x12,\n" +
+	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12,\n" +
+	"\x05cache\x18\x03 \x01(\v2\x16.kratos.api.Data.CacheR\x05cache\x1a\xf6\x05\n" +
+	"\bDatabase\x12]\n" +
+	"\x06driver\x18\x01 \x01(\tBE\xfaBBr@R\bpostgresR\n" +
+	"postgresqlR\x05mysqlR\x06sqliteR\asqlite3R\tsqlserverR\x05mssqlR\x06driver\x12\x1f\n" +
+	"\x06source\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\x06source\x12-\n" +
+	"\x0emax_open_conns\x18\x03 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\fmaxOpenConns\x12-\n" +
+	"\x0emax_idle_conns\x18\x04 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\fmaxIdleConns\x12O\n" +
+	"\x11conn_max_lifetime\x18\x05 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x0fconnMaxLifetime\x12P\n" +
+	"\x12conn_max_idle_time\x18\x06 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x0fconnMaxIdleTime\x12L\n" +
+	"\x0fconnect_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x0econnectTimeout\x120\n" +
+	"\x0fconnect_retries\x18\b \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\x0econnectRetries\x12W\n" +
+	"\x15connect_retry_backoff\x18\t \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x13connectRetryBackoff\x12(\n" +
+	"\breplicas\x18\n" +
+	" \x03(\tB\f\xfaB\t\x92\x01\x06\"\x04r\x02\x10\x01R\breplicas\x12f\n" +
+	"\x1dreplica_health_check_interval\x18\v \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\x1areplicaHealthCheckInterval\x1a\xa2\x03\n" +
+	"\x05Redis\x129\n" +
+	"\anetwork\x18\x01 \x01(\tB\x1f\xfaB\x1cr\x1aR\x03tcpR\x04tcp4R\x04tcp6R\x04unix\xd0\x01\x01R\anetwork\x12O\n" +
+	"\x04addr\x18\x02 \x01(\tB;\xfaB8r621^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$\xd0\x01\x01R\x04addr\x12F\n" +
+	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\vreadTimeout\x12H\n" +
+	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\fwriteTimeout\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x17\n" +
+	"\x02db\x18\x06 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\x02db\x12F\n" +
+	"\fdial_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\vdialTimeout\x1a\xa1\x01\n" +
+	"\x05Cache\x12\x18\n" +
+	"\adisable\x18\x01 \x01(\bR\adisable\x12>\n" +
+	"\bitem_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\aitemTtl\x12>\n" +
+	"\blist_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\b\xfaB\x05\xaa\x01\x02*\x00R\alistTtl*H\n" +
 	"\bLogLevel\x12\b\n" +
 	"\x04INFO\x10\x00\x12\x12\n" +
 	"\x05DEBUG\x10\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\x12\b\n" +
@@ -704,41 +1713,74 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 }
 
 var file_conf_conf_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_conf_conf_proto_goTypes = []any{
 	(LogLevel)(0),               // 0: kratos.api.LogLevel
 	(FormatType)(0),             // 1: kratos.api.FormatType
 	(*Bootstrap)(nil),           // 2: kratos.api.Bootstrap
 	(*Log)(nil),                 // 3: kratos.api.Log
-	(*Metrics)(nil),             // 4: kratos.api.Metrics
-	(*Server)(nil),              // 5: kratos.api.Server
-	(*Data)(nil),                // 6: kratos.api.Data
-	(*Server_HTTP)(nil),         // 7: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 8: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 9: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 10: kratos.api.Data.Redis
-	(*durationpb.Duration)(nil), // 11: google.protobuf.Duration
+	(*Tracing)(nil),             // 4: kratos.api.Tracing
+	(*Metrics)(nil),             // 5: kratos.api.Metrics
+	(*Server)(nil),              // 6: kratos.api.Server
+	(*Data)(nil),                // 7: kratos.api.Data
+	(*Log_Output)(nil),          // 8: kratos.api.Log.Output
+	(*Log_Sampling)(nil),        // 9: kratos.api.Log.Sampling
+	(*Log_Redaction)(nil),       // 10: kratos.api.Log.Redaction
+	(*Metrics_BasicAuth)(nil),   // 11: kratos.api.Metrics.BasicAuth
+	(*Metrics_TLS)(nil),         // 12: kratos.api.Metrics.TLS
+	(*Server_HTTP)(nil),         // 13: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),         // 14: kratos.api.Server.GRPC
+	(*Server_GraphQL)(nil),      // 15: kratos.api.Server.GraphQL
+	(*Server_Shutdown)(nil),     // 16: kratos.api.Server.Shutdown
+	(*Data_Database)(nil),       // 17: kratos.api.Data.Database
+	(*Data_Redis)(nil),          // 18: kratos.api.Data.Redis
+	(*Data_Cache)(nil),          // 19: kratos.api.Data.Cache
+	(*durationpb.Duration)(nil), // 20: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
-	5,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	6,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	6,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	7,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
 	3,  // 2: kratos.api.Bootstrap.log:type_name -> kratos.api.Log
-	4,  // 3: kratos.api.Bootstrap.metrics:type_name -> kratos.api.Metrics
-	0,  // 4: kratos.api.Log.level:type_name -> kratos.api.LogLevel
-	1,  // 5: kratos.api.Log.format:type_name -> kratos.api.FormatType
-	7,  // 6: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	8,  // 7: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	9,  // 8: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	10, // 9: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	11, // 10: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	11, // 11: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	11, // 12: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	11, // 13: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	5,  // 3: kratos.api.Bootstrap.metrics:type_name -> kratos.api.Metrics
+	4,  // 4: kratos.api.Bootstrap.tracing:type_name -> kratos.api.Tracing
+	0,  // 5: kratos.api.Log.level:type_name -> kratos.api.LogLevel
+	1,  // 6: kratos.api.Log.format:type_name -> kratos.api.FormatType
+	8,  // 7: kratos.api.Log.outputs:type_name -> kratos.api.Log.Output
+	9,  // 8: kratos.api.Log.sampling:type_name -> kratos.api.Log.Sampling
+	10, // 9: kratos.api.Log.redaction:type_name -> kratos.api.Log.Redaction
+	20, // 10: kratos.api.Tracing.export_timeout:type_name -> google.protobuf.Duration
+	11, // 11: kratos.api.Metrics.basic_auth:type_name -> kratos.api.Metrics.BasicAuth
+	12, // 12: kratos.api.Metrics.tls:type_name -> kratos.api.Metrics.TLS
+	13, // 13: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	14, // 14: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	15, // 15: kratos.api.Server.graphql:type_name -> kratos.api.Server.GraphQL
+	16, // 16: kratos.api.Server.shutdown:type_name -> kratos.api.Server.Shutdown
+	17, // 17: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	18, // 18: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	19, // 19: kratos.api.Data.cache:type_name -> kratos.api.Data.Cache
+	0,  // 20: kratos.api.Log.Output.min_level:type_name -> kratos.api.LogLevel
+	0,  // 21: kratos.api.Log.Output.max_level:type_name -> kratos.api.LogLevel
+	20, // 22: kratos.api.Log.Output.max_age:type_name -> google.protobuf.Duration
+	20, // 23: kratos.api.Log.Sampling.tick:type_name -> google.protobuf.Duration
+	20, // 24: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	20, // 25: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	20, // 26: kratos.api.Server.Shutdown.pre_stop_delay:type_name -> google.protobuf.Duration
+	20, // 27: kratos.api.Server.Shutdown.grace_timeout:type_name -> google.protobuf.Duration
+	20, // 28: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
+	20, // 29: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
+	20, // 30: kratos.api.Data.Database.connect_timeout:type_name -> google.protobuf.Duration
+	20, // 31: kratos.api.Data.Database.connect_retry_backoff:type_name -> google.protobuf.Duration
+	20, // 32: kratos.api.Data.Database.replica_health_check_interval:type_name -> google.protobuf.Duration
+	20, // 33: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	20, // 34: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	20, // 35: kratos.api.Data.Redis.dial_timeout:type_name -> google.protobuf.Duration
+	20, // 36: kratos.api.Data.Cache.item_ttl:type_name -> google.protobuf.Duration
+	20, // 37: kratos.api.Data.Cache.list_ttl:type_name -> google.protobuf.Duration
+	38, // [38:38] is the sub-list for method output_type
+	38, // [38:38] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -746,13 +1788,15 @@ func file_conf_conf_proto_init() {
 	if File_conf_conf_proto != nil {
 		return
 	}
+	file_conf_conf_proto_msgTypes[2].OneofWrappers = []any{}
+	file_conf_conf_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   9,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
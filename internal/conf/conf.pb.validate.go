@@ -0,0 +1,3496 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: conf/conf.proto
+
+package conf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Bootstrap with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Bootstrap) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Bootstrap with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BootstrapMultiError, or nil
+// if none found.
+func (m *Bootstrap) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Bootstrap) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetServer() == nil {
+		err := BootstrapValidationError{
+			field:  "Server",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetServer()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Server",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Server",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetServer()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Server",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetData() == nil {
+		err := BootstrapValidationError{
+			field:  "Data",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLog()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Log",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Log",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLog()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Log",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetrics()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Metrics",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Metrics",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetrics()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Metrics",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTracing()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Tracing",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BootstrapValidationError{
+					field:  "Tracing",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTracing()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BootstrapValidationError{
+				field:  "Tracing",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return BootstrapMultiError(errors)
+	}
+
+	return nil
+}
+
+// BootstrapMultiError is an error wrapping multiple validation errors returned
+// by Bootstrap.ValidateAll() if the designated constraints aren't met.
+type BootstrapMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BootstrapMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BootstrapMultiError) AllErrors() []error { return m }
+
+// BootstrapValidationError is the validation error returned by
+// Bootstrap.Validate if the designated constraints aren't met.
+type BootstrapValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BootstrapValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BootstrapValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BootstrapValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BootstrapValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BootstrapValidationError) ErrorName() string { return "BootstrapValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BootstrapValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBootstrap.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BootstrapValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BootstrapValidationError{}
+
+// Validate checks the field values on Log with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Log) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Log with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in LogMultiError, or nil if none found.
+func (m *Log) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Log) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := LogLevel_name[int32(m.GetLevel())]; !ok {
+		err := LogValidationError{
+			field:  "Level",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := FormatType_name[int32(m.GetFormat())]; !ok {
+		err := LogValidationError{
+			field:  "Format",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Color
+
+	for idx, item := range m.GetOutputs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LogValidationError{
+						field:  fmt.Sprintf("Outputs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LogValidationError{
+						field:  fmt.Sprintf("Outputs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LogValidationError{
+					field:  fmt.Sprintf("Outputs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetSampling()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LogValidationError{
+					field:  "Sampling",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LogValidationError{
+					field:  "Sampling",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSampling()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LogValidationError{
+				field:  "Sampling",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for TimeFormat
+
+	// no validation rules for DisableCaller
+
+	// no validation rules for Stacktrace
+
+	if all {
+		switch v := interface{}(m.GetRedaction()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LogValidationError{
+					field:  "Redaction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LogValidationError{
+					field:  "Redaction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRedaction()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LogValidationError{
+				field:  "Redaction",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return LogMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogMultiError is an error wrapping multiple validation errors returned by
+// Log.ValidateAll() if the designated constraints aren't met.
+type LogMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogMultiError) AllErrors() []error { return m }
+
+// LogValidationError is the validation error returned by Log.Validate if the
+// designated constraints aren't met.
+type LogValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogValidationError) ErrorName() string { return "LogValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLog.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogValidationError{}
+
+// Validate checks the field values on Tracing with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Tracing) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Tracing with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in TracingMultiError, or nil if none found.
+func (m *Tracing) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Tracing) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetEndpoint() != "" {
+
+		if !_Tracing_Endpoint_Pattern.MatchString(m.GetEndpoint()) {
+			err := TracingValidationError{
+				field:  "Endpoint",
+				reason: "value does not match regex pattern \"^((\\\\[[0-9a-fA-F:.]+\\\\]|[^:\\\\[\\\\]]*):[0-9]{1,5}|/.+)$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Insecure
+
+	if d := m.GetExportTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = TracingValidationError{
+				field:  "ExportTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := TracingValidationError{
+					field:  "ExportTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if m.SampleRatio != nil {
+
+		if val := m.GetSampleRatio(); val < 0 || val > 1 {
+			err := TracingValidationError{
+				field:  "SampleRatio",
+				reason: "value must be inside range [0, 1]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return TracingMultiError(errors)
+	}
+
+	return nil
+}
+
+// TracingMultiError is an error wrapping multiple validation errors returned
+// by Tracing.ValidateAll() if the designated constraints aren't met.
+type TracingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TracingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TracingMultiError) AllErrors() []error { return m }
+
+// TracingValidationError is the validation error returned by Tracing.Validate
+// if the designated constraints aren't met.
+type TracingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TracingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TracingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TracingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TracingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TracingValidationError) ErrorName() string { return "TracingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TracingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTracing.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TracingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TracingValidationError{}
+
+var _Tracing_Endpoint_Pattern = regexp.MustCompile("^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$")
+
+// Validate checks the field values on Metrics with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Metrics) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Metrics with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in MetricsMultiError, or nil if none found.
+func (m *Metrics) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Metrics) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAddr() != "" {
+
+		if !_Metrics_Addr_Pattern.MatchString(m.GetAddr()) {
+			err := MetricsValidationError{
+				field:  "Addr",
+				reason: "value does not match regex pattern \"^((\\\\[[0-9a-fA-F:.]+\\\\]|[^:\\\\[\\\\]]*):[0-9]{1,5}|/.+)$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Disable
+
+	if m.GetNamespace() != "" {
+
+		if !_Metrics_Namespace_Pattern.MatchString(m.GetNamespace()) {
+			err := MetricsValidationError{
+				field:  "Namespace",
+				reason: "value does not match regex pattern \"^[a-zA-Z_][a-zA-Z0-9_]*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	_Metrics_RequestBuckets_Unique := make(map[float64]struct{}, len(m.GetRequestBuckets()))
+
+	for idx, item := range m.GetRequestBuckets() {
+		_, _ = idx, item
+
+		if _, exists := _Metrics_RequestBuckets_Unique[item]; exists {
+			err := MetricsValidationError{
+				field:  fmt.Sprintf("RequestBuckets[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_Metrics_RequestBuckets_Unique[item] = struct{}{}
+		}
+
+		if item <= 0 {
+			err := MetricsValidationError{
+				field:  fmt.Sprintf("RequestBuckets[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	_Metrics_DbBuckets_Unique := make(map[float64]struct{}, len(m.GetDbBuckets()))
+
+	for idx, item := range m.GetDbBuckets() {
+		_, _ = idx, item
+
+		if _, exists := _Metrics_DbBuckets_Unique[item]; exists {
+			err := MetricsValidationError{
+				field:  fmt.Sprintf("DbBuckets[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_Metrics_DbBuckets_Unique[item] = struct{}{}
+		}
+
+		if item <= 0 {
+			err := MetricsValidationError{
+				field:  fmt.Sprintf("DbBuckets[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetMaxRoutes() < 0 {
+		err := MetricsValidationError{
+			field:  "MaxRoutes",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetBasicAuth()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MetricsValidationError{
+					field:  "BasicAuth",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MetricsValidationError{
+					field:  "BasicAuth",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBasicAuth()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MetricsValidationError{
+				field:  "BasicAuth",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTls()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MetricsValidationError{
+					field:  "Tls",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MetricsValidationError{
+					field:  "Tls",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTls()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MetricsValidationError{
+				field:  "Tls",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Pprof
+
+	// no validation rules for Admin
+
+	if len(errors) > 0 {
+		return MetricsMultiError(errors)
+	}
+
+	return nil
+}
+
+// MetricsMultiError is an error wrapping multiple validation errors returned
+// by Metrics.ValidateAll() if the designated constraints aren't met.
+type MetricsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MetricsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MetricsMultiError) AllErrors() []error { return m }
+
+// MetricsValidationError is the validation error returned by Metrics.Validate
+// if the designated constraints aren't met.
+type MetricsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MetricsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MetricsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MetricsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MetricsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MetricsValidationError) ErrorName() string { return "MetricsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MetricsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMetrics.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MetricsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MetricsValidationError{}
+
+var _Metrics_Addr_Pattern = regexp.MustCompile("^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$")
+
+var _Metrics_Namespace_Pattern = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// Validate checks the field values on Server with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in ServerMultiError, or nil if none found.
+func (m *Server) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetHttp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Http",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Http",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetHttp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Http",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGrpc() == nil {
+		err := ServerValidationError{
+			field:  "Grpc",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetGrpc()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Grpc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Grpc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetGrpc()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Grpc",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetGraphql()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Graphql",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Graphql",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetGraphql()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Graphql",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetShutdown()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Shutdown",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Shutdown",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetShutdown()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Shutdown",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ServerMultiError(errors)
+	}
+
+	return nil
+}
+
+// ServerMultiError is an error wrapping multiple validation errors returned by
+// Server.ValidateAll() if the designated constraints aren't met.
+type ServerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ServerMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ServerMultiError) AllErrors() []error { return m }
+
+// ServerValidationError is the validation error returned by Server.Validate if
+// the designated constraints aren't met.
+type ServerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ServerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ServerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ServerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ServerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ServerValidationError) ErrorName() string { return "ServerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ServerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ServerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ServerValidationError{}
+
+// Validate checks the field values on Data with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Data) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Data with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in DataMultiError, or nil if none found.
+func (m *Data) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Data) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetDatabase() == nil {
+		err := DataValidationError{
+			field:  "Database",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetDatabase()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Database",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Database",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDatabase()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "Database",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRedis()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Redis",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Redis",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRedis()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "Redis",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCache()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Cache",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Cache",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCache()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "Cache",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DataMultiError(errors)
+	}
+
+	return nil
+}
+
+// DataMultiError is an error wrapping multiple validation errors returned by
+// Data.ValidateAll() if the designated constraints aren't met.
+type DataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DataMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DataMultiError) AllErrors() []error { return m }
+
+// DataValidationError is the validation error returned by Data.Validate if the
+// designated constraints aren't met.
+type DataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DataValidationError) ErrorName() string { return "DataValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sData.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DataValidationError{}
+
+// Validate checks the field values on Log_Output with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Log_Output) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Log_Output with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Log_OutputMultiError, or
+// nil if none found.
+func (m *Log_Output) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Log_Output) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetPath()) < 1 {
+		err := Log_OutputValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMaxSizeMb() < 0 {
+		err := Log_OutputValidationError{
+			field:  "MaxSizeMb",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetMaxAge(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Log_OutputValidationError{
+				field:  "MaxAge",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Log_OutputValidationError{
+					field:  "MaxAge",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if m.GetMaxBackups() < 0 {
+		err := Log_OutputValidationError{
+			field:  "MaxBackups",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Compress
+
+	if m.MinLevel != nil {
+
+		if _, ok := LogLevel_name[int32(m.GetMinLevel())]; !ok {
+			err := Log_OutputValidationError{
+				field:  "MinLevel",
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.MaxLevel != nil {
+
+		if _, ok := LogLevel_name[int32(m.GetMaxLevel())]; !ok {
+			err := Log_OutputValidationError{
+				field:  "MaxLevel",
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return Log_OutputMultiError(errors)
+	}
+
+	return nil
+}
+
+// Log_OutputMultiError is an error wrapping multiple validation errors
+// returned by Log_Output.ValidateAll() if the designated constraints aren't met.
+type Log_OutputMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Log_OutputMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Log_OutputMultiError) AllErrors() []error { return m }
+
+// Log_OutputValidationError is the validation error returned by
+// Log_Output.Validate if the designated constraints aren't met.
+type Log_OutputValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Log_OutputValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Log_OutputValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Log_OutputValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Log_OutputValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Log_OutputValidationError) ErrorName() string { return "Log_OutputValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Log_OutputValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLog_Output.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Log_OutputValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Log_OutputValidationError{}
+
+// Validate checks the field values on Log_Sampling with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Log_Sampling) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Log_Sampling with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Log_SamplingMultiError, or
+// nil if none found.
+func (m *Log_Sampling) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Log_Sampling) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if d := m.GetTick(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Log_SamplingValidationError{
+				field:  "Tick",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Log_SamplingValidationError{
+					field:  "Tick",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if m.GetInitial() < 0 {
+		err := Log_SamplingValidationError{
+			field:  "Initial",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetThereafter() < 0 {
+		err := Log_SamplingValidationError{
+			field:  "Thereafter",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return Log_SamplingMultiError(errors)
+	}
+
+	return nil
+}
+
+// Log_SamplingMultiError is an error wrapping multiple validation errors
+// returned by Log_Sampling.ValidateAll() if the designated constraints aren't met.
+type Log_SamplingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Log_SamplingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Log_SamplingMultiError) AllErrors() []error { return m }
+
+// Log_SamplingValidationError is the validation error returned by
+// Log_Sampling.Validate if the designated constraints aren't met.
+type Log_SamplingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Log_SamplingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Log_SamplingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Log_SamplingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Log_SamplingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Log_SamplingValidationError) ErrorName() string { return "Log_SamplingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Log_SamplingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLog_Sampling.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Log_SamplingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Log_SamplingValidationError{}
+
+// Validate checks the field values on Log_Redaction with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Log_Redaction) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Log_Redaction with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Log_RedactionMultiError, or
+// nil if none found.
+func (m *Log_Redaction) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Log_Redaction) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Disable
+
+	for idx, item := range m.GetKeys() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := Log_RedactionValidationError{
+				field:  fmt.Sprintf("Keys[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	for idx, item := range m.GetPatterns() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := Log_RedactionValidationError{
+				field:  fmt.Sprintf("Patterns[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return Log_RedactionMultiError(errors)
+	}
+
+	return nil
+}
+
+// Log_RedactionMultiError is an error wrapping multiple validation errors
+// returned by Log_Redaction.ValidateAll() if the designated constraints
+// aren't met.
+type Log_RedactionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Log_RedactionMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Log_RedactionMultiError) AllErrors() []error { return m }
+
+// Log_RedactionValidationError is the validation error returned by
+// Log_Redaction.Validate if the designated constraints aren't met.
+type Log_RedactionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Log_RedactionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Log_RedactionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Log_RedactionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Log_RedactionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Log_RedactionValidationError) ErrorName() string { return "Log_RedactionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Log_RedactionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLog_Redaction.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Log_RedactionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Log_RedactionValidationError{}
+
+// Validate checks the field values on Metrics_BasicAuth with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Metrics_BasicAuth) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Metrics_BasicAuth with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Metrics_BasicAuthMultiError, or nil if none found.
+func (m *Metrics_BasicAuth) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Metrics_BasicAuth) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUsername()) < 1 {
+		err := Metrics_BasicAuthValidationError{
+			field:  "Username",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPassword()) < 1 {
+		err := Metrics_BasicAuthValidationError{
+			field:  "Password",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return Metrics_BasicAuthMultiError(errors)
+	}
+
+	return nil
+}
+
+// Metrics_BasicAuthMultiError is an error wrapping multiple validation errors
+// returned by Metrics_BasicAuth.ValidateAll() if the designated constraints
+// aren't met.
+type Metrics_BasicAuthMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Metrics_BasicAuthMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Metrics_BasicAuthMultiError) AllErrors() []error { return m }
+
+// Metrics_BasicAuthValidationError is the validation error returned by
+// Metrics_BasicAuth.Validate if the designated constraints aren't met.
+type Metrics_BasicAuthValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Metrics_BasicAuthValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Metrics_BasicAuthValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Metrics_BasicAuthValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Metrics_BasicAuthValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Metrics_BasicAuthValidationError) ErrorName() string {
+	return "Metrics_BasicAuthValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Metrics_BasicAuthValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMetrics_BasicAuth.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Metrics_BasicAuthValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Metrics_BasicAuthValidationError{}
+
+// Validate checks the field values on Metrics_TLS with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Metrics_TLS) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Metrics_TLS with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Metrics_TLSMultiError, or
+// nil if none found.
+func (m *Metrics_TLS) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Metrics_TLS) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCertFile()) < 1 {
+		err := Metrics_TLSValidationError{
+			field:  "CertFile",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetKeyFile()) < 1 {
+		err := Metrics_TLSValidationError{
+			field:  "KeyFile",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return Metrics_TLSMultiError(errors)
+	}
+
+	return nil
+}
+
+// Metrics_TLSMultiError is an error wrapping multiple validation errors
+// returned by Metrics_TLS.ValidateAll() if the designated constraints aren't met.
+type Metrics_TLSMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Metrics_TLSMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Metrics_TLSMultiError) AllErrors() []error { return m }
+
+// Metrics_TLSValidationError is the validation error returned by
+// Metrics_TLS.Validate if the designated constraints aren't met.
+type Metrics_TLSValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Metrics_TLSValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Metrics_TLSValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Metrics_TLSValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Metrics_TLSValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Metrics_TLSValidationError) ErrorName() string { return "Metrics_TLSValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Metrics_TLSValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMetrics_TLS.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Metrics_TLSValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Metrics_TLSValidationError{}
+
+// Validate checks the field values on Server_HTTP with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_HTTP) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_HTTP with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_HTTPMultiError, or
+// nil if none found.
+func (m *Server_HTTP) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_HTTP) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetNetwork() != "" {
+
+		if _, ok := _Server_HTTP_Network_InLookup[m.GetNetwork()]; !ok {
+			err := Server_HTTPValidationError{
+				field:  "Network",
+				reason: "value must be in list [tcp tcp4 tcp6 unix]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetAddr() != "" {
+
+		if !_Server_HTTP_Addr_Pattern.MatchString(m.GetAddr()) {
+			err := Server_HTTPValidationError{
+				field:  "Addr",
+				reason: "value does not match regex pattern \"^((\\\\[[0-9a-fA-F:.]+\\\\]|[^:\\\\[\\\\]]*):[0-9]{1,5}|/.+)$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Server_HTTPValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Server_HTTPValidationError{
+					field:  "Timeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_HTTPMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_HTTPMultiError is an error wrapping multiple validation errors
+// returned by Server_HTTP.ValidateAll() if the designated constraints aren't met.
+type Server_HTTPMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_HTTPMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_HTTPMultiError) AllErrors() []error { return m }
+
+// Server_HTTPValidationError is the validation error returned by
+// Server_HTTP.Validate if the designated constraints aren't met.
+type Server_HTTPValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_HTTPValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_HTTPValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_HTTPValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_HTTPValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_HTTPValidationError) ErrorName() string { return "Server_HTTPValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_HTTPValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_HTTP.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_HTTPValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_HTTPValidationError{}
+
+var _Server_HTTP_Network_InLookup = map[string]struct{}{
+	"tcp":  {},
+	"tcp4": {},
+	"tcp6": {},
+	"unix": {},
+}
+
+var _Server_HTTP_Addr_Pattern = regexp.MustCompile("^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$")
+
+// Validate checks the field values on Server_GRPC with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_GRPC) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_GRPC with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_GRPCMultiError, or
+// nil if none found.
+func (m *Server_GRPC) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_GRPC) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetNetwork() != "" {
+
+		if _, ok := _Server_GRPC_Network_InLookup[m.GetNetwork()]; !ok {
+			err := Server_GRPCValidationError{
+				field:  "Network",
+				reason: "value must be in list [tcp tcp4 tcp6 unix]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetAddr() != "" {
+
+		if !_Server_GRPC_Addr_Pattern.MatchString(m.GetAddr()) {
+			err := Server_GRPCValidationError{
+				field:  "Addr",
+				reason: "value does not match regex pattern \"^((\\\\[[0-9a-fA-F:.]+\\\\]|[^:\\\\[\\\\]]*):[0-9]{1,5}|/.+)$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Server_GRPCValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Server_GRPCValidationError{
+					field:  "Timeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_GRPCMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_GRPCMultiError is an error wrapping multiple validation errors
+// returned by Server_GRPC.ValidateAll() if the designated constraints aren't met.
+type Server_GRPCMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_GRPCMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_GRPCMultiError) AllErrors() []error { return m }
+
+// Server_GRPCValidationError is the validation error returned by
+// Server_GRPC.Validate if the designated constraints aren't met.
+type Server_GRPCValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_GRPCValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_GRPCValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_GRPCValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_GRPCValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_GRPCValidationError) ErrorName() string { return "Server_GRPCValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_GRPCValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_GRPC.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_GRPCValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_GRPCValidationError{}
+
+var _Server_GRPC_Network_InLookup = map[string]struct{}{
+	"tcp":  {},
+	"tcp4": {},
+	"tcp6": {},
+	"unix": {},
+}
+
+var _Server_GRPC_Addr_Pattern = regexp.MustCompile("^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$")
+
+// Validate checks the field values on Server_GraphQL with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_GraphQL) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_GraphQL with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_GraphQLMultiError,
+// or nil if none found.
+func (m *Server_GraphQL) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_GraphQL) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Disable
+
+	if m.GetPath() != "" {
+
+		if !strings.HasPrefix(m.GetPath(), "/") {
+			err := Server_GraphQLValidationError{
+				field:  "Path",
+				reason: "value does not have prefix \"/\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Playground
+
+	if m.GetPlaygroundPath() != "" {
+
+		if !strings.HasPrefix(m.GetPlaygroundPath(), "/") {
+			err := Server_GraphQLValidationError{
+				field:  "PlaygroundPath",
+				reason: "value does not have prefix \"/\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Introspection
+
+	if m.GetComplexityLimit() < 0 {
+		err := Server_GraphQLValidationError{
+			field:  "ComplexityLimit",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetDepthLimit() < 0 {
+		err := Server_GraphQLValidationError{
+			field:  "DepthLimit",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetApqCacheSize() < 0 {
+		err := Server_GraphQLValidationError{
+			field:  "ApqCacheSize",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return Server_GraphQLMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_GraphQLMultiError is an error wrapping multiple validation errors
+// returned by Server_GraphQL.ValidateAll() if the designated constraints
+// aren't met.
+type Server_GraphQLMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_GraphQLMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_GraphQLMultiError) AllErrors() []error { return m }
+
+// Server_GraphQLValidationError is the validation error returned by
+// Server_GraphQL.Validate if the designated constraints aren't met.
+type Server_GraphQLValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_GraphQLValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_GraphQLValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_GraphQLValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_GraphQLValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_GraphQLValidationError) ErrorName() string { return "Server_GraphQLValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_GraphQLValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_GraphQL.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_GraphQLValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_GraphQLValidationError{}
+
+// Validate checks the field values on Server_Shutdown with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Server_Shutdown) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Shutdown with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Server_ShutdownMultiError, or nil if none found.
+func (m *Server_Shutdown) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Shutdown) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if d := m.GetPreStopDelay(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Server_ShutdownValidationError{
+				field:  "PreStopDelay",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur < gte {
+				err := Server_ShutdownValidationError{
+					field:  "PreStopDelay",
+					reason: "value must be greater than or equal to 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetGraceTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Server_ShutdownValidationError{
+				field:  "GraceTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Server_ShutdownValidationError{
+					field:  "GraceTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_ShutdownMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_ShutdownMultiError is an error wrapping multiple validation errors
+// returned by Server_Shutdown.ValidateAll() if the designated constraints
+// aren't met.
+type Server_ShutdownMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_ShutdownMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_ShutdownMultiError) AllErrors() []error { return m }
+
+// Server_ShutdownValidationError is the validation error returned by
+// Server_Shutdown.Validate if the designated constraints aren't met.
+type Server_ShutdownValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_ShutdownValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_ShutdownValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_ShutdownValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_ShutdownValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_ShutdownValidationError) ErrorName() string { return "Server_ShutdownValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_ShutdownValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Shutdown.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_ShutdownValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_ShutdownValidationError{}
+
+// Validate checks the field values on Data_Database with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Data_Database) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Data_Database with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_DatabaseMultiError, or
+// nil if none found.
+func (m *Data_Database) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Data_Database) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _Data_Database_Driver_InLookup[m.GetDriver()]; !ok {
+		err := Data_DatabaseValidationError{
+			field:  "Driver",
+			reason: "value must be in list [postgres postgresql mysql sqlite sqlite3 sqlserver mssql]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetSource()) < 1 {
+		err := Data_DatabaseValidationError{
+			field:  "Source",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMaxOpenConns() < 0 {
+		err := Data_DatabaseValidationError{
+			field:  "MaxOpenConns",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMaxIdleConns() < 0 {
+		err := Data_DatabaseValidationError{
+			field:  "MaxIdleConns",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetConnMaxLifetime(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_DatabaseValidationError{
+				field:  "ConnMaxLifetime",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_DatabaseValidationError{
+					field:  "ConnMaxLifetime",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetConnMaxIdleTime(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_DatabaseValidationError{
+				field:  "ConnMaxIdleTime",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_DatabaseValidationError{
+					field:  "ConnMaxIdleTime",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetConnectTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_DatabaseValidationError{
+				field:  "ConnectTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_DatabaseValidationError{
+					field:  "ConnectTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if m.GetConnectRetries() < 0 {
+		err := Data_DatabaseValidationError{
+			field:  "ConnectRetries",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetConnectRetryBackoff(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_DatabaseValidationError{
+				field:  "ConnectRetryBackoff",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_DatabaseValidationError{
+					field:  "ConnectRetryBackoff",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	for idx, item := range m.GetReplicas() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := Data_DatabaseValidationError{
+				field:  fmt.Sprintf("Replicas[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if d := m.GetReplicaHealthCheckInterval(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_DatabaseValidationError{
+				field:  "ReplicaHealthCheckInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_DatabaseValidationError{
+					field:  "ReplicaHealthCheckInterval",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Data_DatabaseMultiError(errors)
+	}
+
+	return nil
+}
+
+// Data_DatabaseMultiError is an error wrapping multiple validation errors
+// returned by Data_Database.ValidateAll() if the designated constraints
+// aren't met.
+type Data_DatabaseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Data_DatabaseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Data_DatabaseMultiError) AllErrors() []error { return m }
+
+// Data_DatabaseValidationError is the validation error returned by
+// Data_Database.Validate if the designated constraints aren't met.
+type Data_DatabaseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Data_DatabaseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Data_DatabaseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Data_DatabaseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Data_DatabaseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Data_DatabaseValidationError) ErrorName() string { return "Data_DatabaseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Data_DatabaseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sData_Database.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Data_DatabaseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Data_DatabaseValidationError{}
+
+var _Data_Database_Driver_InLookup = map[string]struct{}{
+	"postgres":   {},
+	"postgresql": {},
+	"mysql":      {},
+	"sqlite":     {},
+	"sqlite3":    {},
+	"sqlserver":  {},
+	"mssql":      {},
+}
+
+// Validate checks the field values on Data_Redis with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Data_Redis) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Data_Redis with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_RedisMultiError, or
+// nil if none found.
+func (m *Data_Redis) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Data_Redis) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetNetwork() != "" {
+
+		if _, ok := _Data_Redis_Network_InLookup[m.GetNetwork()]; !ok {
+			err := Data_RedisValidationError{
+				field:  "Network",
+				reason: "value must be in list [tcp tcp4 tcp6 unix]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetAddr() != "" {
+
+		if !_Data_Redis_Addr_Pattern.MatchString(m.GetAddr()) {
+			err := Data_RedisValidationError{
+				field:  "Addr",
+				reason: "value does not match regex pattern \"^((\\\\[[0-9a-fA-F:.]+\\\\]|[^:\\\\[\\\\]]*):[0-9]{1,5}|/.+)$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if d := m.GetReadTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_RedisValidationError{
+				field:  "ReadTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_RedisValidationError{
+					field:  "ReadTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetWriteTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_RedisValidationError{
+				field:  "WriteTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_RedisValidationError{
+					field:  "WriteTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for Password
+
+	if m.GetDb() < 0 {
+		err := Data_RedisValidationError{
+			field:  "Db",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetDialTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_RedisValidationError{
+				field:  "DialTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_RedisValidationError{
+					field:  "DialTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Data_RedisMultiError(errors)
+	}
+
+	return nil
+}
+
+// Data_RedisMultiError is an error wrapping multiple validation errors
+// returned by Data_Redis.ValidateAll() if the designated constraints aren't met.
+type Data_RedisMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Data_RedisMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Data_RedisMultiError) AllErrors() []error { return m }
+
+// Data_RedisValidationError is the validation error returned by
+// Data_Redis.Validate if the designated constraints aren't met.
+type Data_RedisValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Data_RedisValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Data_RedisValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Data_RedisValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Data_RedisValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Data_RedisValidationError) ErrorName() string { return "Data_RedisValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Data_RedisValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sData_Redis.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Data_RedisValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Data_RedisValidationError{}
+
+var _Data_Redis_Network_InLookup = map[string]struct{}{
+	"tcp":  {},
+	"tcp4": {},
+	"tcp6": {},
+	"unix": {},
+}
+
+var _Data_Redis_Addr_Pattern = regexp.MustCompile("^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$")
+
+// Validate checks the field values on Data_Cache with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Data_Cache) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Data_Cache with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_CacheMultiError, or
+// nil if none found.
+func (m *Data_Cache) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Data_Cache) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Disable
+
+	if d := m.GetItemTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_CacheValidationError{
+				field:  "ItemTtl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_CacheValidationError{
+					field:  "ItemTtl",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetListTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Data_CacheValidationError{
+				field:  "ListTtl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := Data_CacheValidationError{
+					field:  "ListTtl",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Data_CacheMultiError(errors)
+	}
+
+	return nil
+}
+
+// Data_CacheMultiError is an error wrapping multiple validation errors
+// returned by Data_Cache.ValidateAll() if the designated constraints aren't met.
+type Data_CacheMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Data_CacheMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Data_CacheMultiError) AllErrors() []error { return m }
+
+// Data_CacheValidationError is the validation error returned by
+// Data_Cache.Validate if the designated constraints aren't met.
+type Data_CacheValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Data_CacheValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Data_CacheValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Data_CacheValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Data_CacheValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Data_CacheValidationError) ErrorName() string { return "Data_CacheValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Data_CacheValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sData_Cache.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Data_CacheValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Data_CacheValidationError{}
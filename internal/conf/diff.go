@@ -0,0 +1,61 @@
+package conf
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Diff returns the keys of the fields whose values differ between a and b, sorted,
+// e.g. server.http.addr. Unset fields are equal to fields set to their default value.
+func Diff(a, b *Bootstrap) []string {
+	var keys []string
+	for path := range bootstrapFields() {
+		if !fieldValue(a.ProtoReflect(), path).Equal(fieldValue(b.ProtoReflect(), path)) {
+			keys = append(keys, path)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CopyField sets the field at key in dst to its value in src, e.g. to keep
+// the running value of a field whose change is not applied.
+func CopyField(dst, src *Bootstrap, key string) {
+	names := strings.Split(key, ".")
+	d, s := dst.ProtoReflect(), src.ProtoReflect()
+	for _, name := range names[:len(names)-1] {
+		fd := d.Descriptor().Fields().ByName(protoreflect.Name(name))
+		d = d.Mutable(fd).Message()
+		s = s.Get(fd).Message()
+	}
+
+	fd := d.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+	if !s.Has(fd) {
+		d.Clear(fd)
+		return
+	}
+	switch {
+	case fd.IsList():
+		dl, sl := d.Mutable(fd).List(), s.Get(fd).List()
+		dl.Truncate(0)
+		for i := 0; i < sl.Len(); i++ {
+			dl.Append(sl.Get(i))
+		}
+	case fd.Message() != nil:
+		d.Set(fd, protoreflect.ValueOfMessage(proto.Clone(s.Get(fd).Message().Interface()).ProtoReflect()))
+	default:
+		d.Set(fd, s.Get(fd))
+	}
+}
+
+// fieldValue returns the value of the field at path in m, or its default value when unset.
+func fieldValue(m protoreflect.Message, path string) protoreflect.Value {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		m = m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(name))).Message()
+	}
+	return m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1])))
+}
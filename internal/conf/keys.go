@@ -0,0 +1,93 @@
+package conf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey is a key of a config file that is not a Bootstrap field, e.g. a typo.
+type UnknownKey struct {
+	File string
+	Line int
+	Key  string
+}
+
+// String returns the key with its position, e.g. configs/config.yaml:33: log.colour.
+func (k UnknownKey) String() string {
+	return fmt.Sprintf("%s:%d: %s", k.File, k.Line, k.Key)
+}
+
+// UnknownKeys returns the keys of the YAML and JSON config files at path, a file or
+// a directory as for the file config source, that are not Bootstrap fields.
+// Keys are matched against both the proto and the JSON names of the fields.
+func UnknownKeys(path string) ([]UnknownKey, error) {
+	files := []string{path}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, e := range entries {
+			if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+
+	var unknown []UnknownKey
+	for _, file := range files {
+		switch filepath.Ext(file) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(b, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		unknown = append(unknown, unknownKeys(file, doc.Content[0], (*Bootstrap)(nil).ProtoReflect().Descriptor(), "")...)
+	}
+	return unknown, nil
+}
+
+// unknownKeys returns the keys of the mapping node that are not fields of md, recursively.
+func unknownKeys(file string, node *yaml.Node, md protoreflect.MessageDescriptor, prefix string) []UnknownKey {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var unknown []UnknownKey
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fd := md.Fields().ByName(protoreflect.Name(key.Value))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(key.Value)
+		}
+		if fd == nil {
+			unknown = append(unknown, UnknownKey{File: file, Line: key.Line, Key: prefix + key.Value})
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() && !isDuration(fd) {
+			unknown = append(unknown, unknownKeys(file, value, fd.Message(), prefix+key.Value+".")...)
+		}
+	}
+	return unknown
+}
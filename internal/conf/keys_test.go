@@ -0,0 +1,117 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const unknownKeysTestConfig = `server:
+  http:
+    addr: :8000
+    timeout: 1s
+    port: 8000
+  grcp:
+    addr: :9000
+data:
+  database:
+    maxOpenConns: 10
+    max_open_conns: 10
+    conn_max_lifetime: 3600s
+    replicas: [a, b]
+log:
+  colour: true
+  redaction:
+    keys: [ssn]
+    pattern: x
+`
+
+func TestUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml":   unknownKeysTestConfig,
+		"metrics.json":  `{"metrics": {"namespace": "app", "pprof_enabled": true}}`,
+		"empty.yml":     "",
+		"notes.txt":     "not: [a, config]",
+		".hidden.yaml":  "unknown: true",
+		"overrides.yml": "server:\n  grpc:\n    timeout: 2s\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := filepath.Join(dir, "config.yaml")
+	metrics := filepath.Join(dir, "metrics.json")
+
+	tests := []struct {
+		name string
+		path string
+		want []UnknownKey
+	}{
+		{
+			name: "file",
+			path: config,
+			want: []UnknownKey{
+				{File: config, Line: 5, Key: "server.http.port"},
+				{File: config, Line: 6, Key: "server.grcp"},
+				{File: config, Line: 15, Key: "log.colour"},
+				{File: config, Line: 18, Key: "log.redaction.pattern"},
+			},
+		},
+		{
+			name: "directory",
+			path: dir,
+			want: []UnknownKey{
+				{File: config, Line: 5, Key: "server.http.port"},
+				{File: config, Line: 6, Key: "server.grcp"},
+				{File: config, Line: 15, Key: "log.colour"},
+				{File: config, Line: 18, Key: "log.redaction.pattern"},
+				{File: metrics, Line: 1, Key: "metrics.pprof_enabled"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnknownKeys(tt.path)
+			if err != nil {
+				t.Fatalf("UnknownKeys() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UnknownKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := (UnknownKey{File: "configs/config.yaml", Line: 33, Key: "log.colour"}).String(); got != "configs/config.yaml:33: log.colour" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestUnknownKeysErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(invalid, []byte("server: [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := UnknownKeys(invalid); err == nil || !strings.Contains(err.Error(), "failed to parse "+invalid) {
+		t.Errorf("UnknownKeys() of an invalid file error = %v", err)
+	}
+	if _, err := UnknownKeys(filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("UnknownKeys() of a missing path error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestSampleConfigHasNoUnknownKeys(t *testing.T) {
+	unknown, err := UnknownKeys("../../configs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown) != 0 {
+		t.Errorf("UnknownKeys() of the sample config = %v, want none", unknown)
+	}
+}
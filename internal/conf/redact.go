@@ -0,0 +1,67 @@
+package conf
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redact returns a copy of bc safe to print: the secrets resolved from references are masked,
+// as are password fields and the passwords of URL DSNs, e.g. postgres://app:******@db/app.
+func Redact(bc *Bootstrap, secrets *Secrets) *Bootstrap {
+	redacted := proto.Clone(bc).(*Bootstrap)
+	redactMessage(redacted.ProtoReflect(), secrets)
+	return redacted
+}
+
+func redactMessage(m protoreflect.Message, secrets *Secrets) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				l.Set(i, protoreflect.ValueOfString(redactString(fd, l.Get(i).String(), secrets)))
+			}
+		case fd.Kind() == protoreflect.StringKind:
+			m.Set(fd, protoreflect.ValueOfString(redactString(fd, v.String(), secrets)))
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap():
+			redactMessage(v.Message(), secrets)
+		}
+		return true
+	})
+}
+
+func redactString(fd protoreflect.FieldDescriptor, v string, secrets *Secrets) string {
+	if v == "" {
+		return v
+	}
+	if strings.Contains(string(fd.Name()), "password") {
+		return secretMask
+	}
+	if secrets != nil {
+		v = secrets.Mask(v)
+	}
+	return redactURLPassword(v)
+}
+
+// redactURLPassword masks the password in the user info of a URL, e.g. postgres://app:secret@db/app.
+func redactURLPassword(v string) string {
+	i := strings.Index(v, "://")
+	if i < 0 {
+		return v
+	}
+	authority := v[i+3:]
+	if j := strings.IndexAny(authority, "/?#"); j >= 0 {
+		authority = authority[:j]
+	}
+	at := strings.LastIndex(authority, "@")
+	if at < 0 {
+		return v
+	}
+	user, _, ok := strings.Cut(authority[:at], ":")
+	if !ok {
+		return v
+	}
+	return v[:i+3] + user + ":" + secretMask + v[i+3+at:]
+}
@@ -0,0 +1,131 @@
+package conf
+
+import (
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// Results of a reload, recorded by the ReloadMetricer of a Reloader.
+const (
+	ReloadApplied  = "applied"
+	ReloadPartial  = "partial"
+	ReloadRejected = "rejected"
+	ReloadFailed   = "failed"
+)
+
+// ReloadMetricer records the results of reloads.
+type ReloadMetricer interface {
+	RecordReload(result string)
+}
+
+// ApplyFunc applies the reloaded configuration bc to a running component.
+type ApplyFunc func(bc *Bootstrap) error
+
+type reloadHandler struct {
+	keys  []string
+	apply ApplyFunc
+}
+
+// Reloader applies the changes of a reloaded configuration to the running components.
+// Components register the keys they can change without a restart, such as timeouts.
+// Changes to any other key, such as listen addresses, are rejected and reported
+// on every reload until the process is restarted.
+type Reloader struct {
+	mu       sync.Mutex
+	current  *Bootstrap
+	handlers map[string]*reloadHandler
+	metricer ReloadMetricer
+	log      *log.Helper
+}
+
+// NewReloader creates a Reloader for the components running with the configuration current.
+func NewReloader(current *Bootstrap, metricer ReloadMetricer, logger log.Logger) *Reloader {
+	return &Reloader{
+		current:  proto.Clone(current).(*Bootstrap),
+		handlers: make(map[string]*reloadHandler),
+		metricer: metricer,
+		log:      log.NewHelper(log.With(logger, "module", "conf/reload")),
+	}
+}
+
+// Register calls apply on reloads that change any of keys, e.g. server.http.timeout.
+func (r *Reloader) Register(apply ApplyFunc, keys ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h := &reloadHandler{keys: keys, apply: apply}
+	for _, key := range keys {
+		r.handlers[key] = h
+	}
+}
+
+// Reload applies the changes from the running configuration to next.
+// It does nothing when nothing changed.
+func (r *Reloader) Reload(next *Bootstrap) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := Diff(r.current, next)
+	if len(changed) == 0 {
+		return
+	}
+
+	// the configuration the components run with once the reload is done
+	applied := proto.Clone(next).(*Bootstrap)
+	var (
+		handlers []*reloadHandler
+		seen     = make(map[*reloadHandler]bool)
+		accepted []string
+		rejected []string
+	)
+	for _, key := range changed {
+		h, ok := r.handlers[key]
+		if !ok {
+			rejected = append(rejected, key)
+			CopyField(applied, r.current, key)
+			continue
+		}
+		accepted = append(accepted, key)
+		if !seen[h] {
+			seen[h] = true
+			handlers = append(handlers, h)
+		}
+	}
+
+	failed := false
+	for _, h := range handlers {
+		if err := h.apply(applied); err != nil {
+			failed = true
+			r.log.Errorf("failed to apply config changes to %v: %v", h.keys, err)
+			for _, key := range h.keys {
+				CopyField(applied, r.current, key)
+			}
+		}
+	}
+	r.current = applied
+
+	if len(rejected) > 0 {
+		r.log.Warnf("config changes to %v need a restart and were not applied", rejected)
+	}
+	switch {
+	case failed:
+		r.metricer.RecordReload(ReloadFailed)
+	case len(accepted) == 0:
+		r.metricer.RecordReload(ReloadRejected)
+	case len(rejected) > 0:
+		r.log.Infof("config reloaded, applied changes to %v", accepted)
+		r.metricer.RecordReload(ReloadPartial)
+	default:
+		r.log.Infof("config reloaded, applied changes to %v", accepted)
+		r.metricer.RecordReload(ReloadApplied)
+	}
+}
+
+// Failed reports a reload that failed before it could be applied, e.g. because the files do not parse.
+// The running configuration is kept.
+func (r *Reloader) Failed(err error) {
+	r.log.Errorf("failed to reload config, keeping the running config: %v", err)
+	r.metricer.RecordReload(ReloadFailed)
+}
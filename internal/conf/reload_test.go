@@ -0,0 +1,171 @@
+package conf
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// reloadResults records the results of the reloads.
+type reloadResults []string
+
+func (r *reloadResults) RecordReload(result string) {
+	*r = append(*r, result)
+}
+
+// reloadTestConfig returns a config whose http timeout, grpc timeout and http address are the arguments.
+func reloadTestConfig(httpTimeout, grpcTimeout time.Duration, httpAddr string) *Bootstrap {
+	return &Bootstrap{
+		Server: &Server{
+			Http: &Server_HTTP{Addr: httpAddr, Timeout: durationpb.New(httpTimeout)},
+			Grpc: &Server_GRPC{Addr: ":9000", Timeout: durationpb.New(grpcTimeout)},
+		},
+	}
+}
+
+func TestReloader(t *testing.T) {
+	errApply := errors.New("apply failed")
+	initial := reloadTestConfig(time.Second, time.Second, ":8000")
+
+	tests := []struct {
+		name string
+		// failures is the number of times the timeouts handler fails before it succeeds
+		failures int
+		reloads  []*Bootstrap
+		// wantApplied are the HTTP timeouts the handler is called with, successfully or not
+		wantApplied []time.Duration
+		wantResults reloadResults
+		// wantCurrent is the configuration the components run with after the reloads
+		wantCurrent *Bootstrap
+	}{
+		{
+			name:        "unchanged",
+			reloads:     []*Bootstrap{reloadTestConfig(time.Second, time.Second, ":8000")},
+			wantResults: nil,
+			wantCurrent: initial,
+		},
+		{
+			name:        "applied",
+			reloads:     []*Bootstrap{reloadTestConfig(2*time.Second, time.Second, ":8000")},
+			wantApplied: []time.Duration{2 * time.Second},
+			wantResults: reloadResults{ReloadApplied},
+			wantCurrent: reloadTestConfig(2*time.Second, time.Second, ":8000"),
+		},
+		{
+			name: "keys of one handler applied once",
+			reloads: []*Bootstrap{
+				reloadTestConfig(2*time.Second, 3*time.Second, ":8000"),
+			},
+			wantApplied: []time.Duration{2 * time.Second},
+			wantResults: reloadResults{ReloadApplied},
+			wantCurrent: reloadTestConfig(2*time.Second, 3*time.Second, ":8000"),
+		},
+		{
+			name: "rejected on every reload",
+			reloads: []*Bootstrap{
+				reloadTestConfig(time.Second, time.Second, ":8001"),
+				reloadTestConfig(time.Second, time.Second, ":8001"),
+			},
+			wantResults: reloadResults{ReloadRejected, ReloadRejected},
+			wantCurrent: initial,
+		},
+		{
+			name: "partial",
+			reloads: []*Bootstrap{
+				reloadTestConfig(2*time.Second, time.Second, ":8001"),
+				// the rejected change is reported again, the applied one is not applied again
+				reloadTestConfig(2*time.Second, time.Second, ":8001"),
+			},
+			wantApplied: []time.Duration{2 * time.Second},
+			wantResults: reloadResults{ReloadPartial, ReloadRejected},
+			wantCurrent: reloadTestConfig(2*time.Second, time.Second, ":8000"),
+		},
+		{
+			name:     "failed apply rolled back and retried",
+			failures: 1,
+			reloads: []*Bootstrap{
+				reloadTestConfig(2*time.Second, time.Second, ":8000"),
+				reloadTestConfig(2*time.Second, time.Second, ":8000"),
+			},
+			wantApplied: []time.Duration{2 * time.Second, 2 * time.Second},
+			wantResults: reloadResults{ReloadFailed, ReloadApplied},
+			wantCurrent: reloadTestConfig(2*time.Second, time.Second, ":8000"),
+		},
+		{
+			name:     "failed apply rolled back",
+			failures: 1,
+			reloads: []*Bootstrap{
+				reloadTestConfig(2*time.Second, time.Second, ":8000"),
+				// back to the running config, there is nothing to apply
+				reloadTestConfig(time.Second, time.Second, ":8000"),
+			},
+			wantApplied: []time.Duration{2 * time.Second},
+			wantResults: reloadResults{ReloadFailed},
+			wantCurrent: initial,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results reloadResults
+			r := NewReloader(initial, &results, log.NewStdLogger(io.Discard))
+
+			var applied []time.Duration
+			failures := tt.failures
+			r.Register(func(bc *Bootstrap) error {
+				applied = append(applied, bc.GetServer().GetHttp().GetTimeout().AsDuration())
+				if failures > 0 {
+					failures--
+					return errApply
+				}
+				return nil
+			}, "server.http.timeout", "server.grpc.timeout")
+
+			for _, next := range tt.reloads {
+				r.Reload(next)
+			}
+			if !slices.Equal(applied, tt.wantApplied) {
+				t.Errorf("applied timeouts = %v, want %v", applied, tt.wantApplied)
+			}
+			if !slices.Equal(results, tt.wantResults) {
+				t.Errorf("results = %v, want %v", results, tt.wantResults)
+			}
+			if !proto.Equal(r.current, tt.wantCurrent) {
+				t.Errorf("current = %v, want %v", r.current, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestReloaderFailed(t *testing.T) {
+	var results reloadResults
+	initial := reloadTestConfig(time.Second, time.Second, ":8000")
+	r := NewReloader(initial, &results, log.NewStdLogger(io.Discard))
+
+	r.Failed(errors.New("invalid yaml"))
+	if !slices.Equal(results, reloadResults{ReloadFailed}) {
+		t.Errorf("results = %v, want %v", results, reloadResults{ReloadFailed})
+	}
+	if !proto.Equal(r.current, initial) {
+		t.Errorf("current = %v, want %v", r.current, initial)
+	}
+}
+
+func TestReloaderKeepsItsCopy(t *testing.T) {
+	var results reloadResults
+	initial := reloadTestConfig(time.Second, time.Second, ":8000")
+	r := NewReloader(initial, &results, log.NewStdLogger(io.Discard))
+	r.Register(func(bc *Bootstrap) error { return nil }, "server.http.timeout")
+
+	// changing the config the reloader was created with changes nothing it runs with
+	initial.Server.Http.Timeout = durationpb.New(time.Minute)
+	r.Reload(reloadTestConfig(time.Minute, time.Second, ":8000"))
+	if !slices.Equal(results, reloadResults{ReloadApplied}) {
+		t.Errorf("results = %v, want %v", results, reloadResults{ReloadApplied})
+	}
+}
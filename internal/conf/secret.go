@@ -0,0 +1,143 @@
+package conf
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// secretMask replaces the resolved secrets in printed config values.
+const secretMask = "******"
+
+// secretRefPattern matches the secret references in config values:
+// ${env:NAME} is the value of the environment variable NAME, and
+// ${file:PATH} is the content of the file at PATH without its trailing newline.
+var secretRefPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// SecretRef is a config value that references secrets.
+type SecretRef struct {
+	// Key is the path of the field, e.g. data.database.source.
+	Key string
+	// Value is the value before resolution, e.g. postgres://app:${env:DB_PASSWORD}@db/app.
+	Value string
+}
+
+// Secrets records the secret references resolved by a config, so that the
+// resolved secrets can be masked when the config is printed.
+type Secrets struct {
+	refs    []SecretRef
+	secrets []string
+}
+
+// Refs returns the config values that reference secrets, sorted by key.
+func (s *Secrets) Refs() []SecretRef {
+	refs := append([]SecretRef(nil), s.refs...)
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Key < refs[j].Key
+	})
+	return refs
+}
+
+// Mask replaces the resolved secrets in v.
+func (s *Secrets) Mask(v string) string {
+	for _, secret := range s.secrets {
+		v = strings.ReplaceAll(v, secret, secretMask)
+	}
+	return v
+}
+
+// Resolver returns a config resolver, for config.WithResolver, that replaces the secret
+// references in every config value and records them in s. A reference that cannot be
+// resolved, or that is neither an env nor a file reference, is an error.
+func (s *Secrets) Resolver() func(map[string]interface{}) error {
+	return func(values map[string]interface{}) error {
+		// the whole config is resolved again after every change
+		s.refs, s.secrets = nil, nil
+		return s.resolveMap(values, "")
+	}
+}
+
+func (s *Secrets) resolveMap(m map[string]interface{}, prefix string) error {
+	for k, v := range m {
+		resolved, err := s.resolveValue(v, prefix+k)
+		if err != nil {
+			return err
+		}
+		m[k] = resolved
+	}
+	return nil
+}
+
+func (s *Secrets) resolveValue(v interface{}, key string) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return s.resolveString(v, key)
+	case map[string]interface{}:
+		return v, s.resolveMap(v, key+".")
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := s.resolveValue(item, key)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func (s *Secrets) resolveString(v, key string) (string, error) {
+	if !strings.Contains(v, "${") {
+		return v, nil
+	}
+
+	var err error
+	resolved := secretRefPattern.ReplaceAllStringFunc(v, func(ref string) string {
+		if err != nil {
+			return ref
+		}
+		var secret string
+		secret, err = resolveSecretRef(ref[2 : len(ref)-1])
+		if err != nil {
+			err = fmt.Errorf("failed to resolve %s in %s: %w", ref, key, err)
+			return ref
+		}
+		if secret != "" {
+			s.secrets = append(s.secrets, secret)
+		}
+		return secret
+	})
+	if err != nil {
+		return "", err
+	}
+	s.refs = append(s.refs, SecretRef{Key: key, Value: v})
+	return resolved, nil
+}
+
+// resolveSecretRef returns the secret referenced by ref, e.g. env:DB_PASSWORD.
+func resolveSecretRef(ref string) (string, error) {
+	kind, name, _ := strings.Cut(ref, ":")
+	if name == "" {
+		return "", fmt.Errorf("invalid reference, expected ${env:NAME} or ${file:PATH}")
+	}
+	switch kind {
+	case "env":
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	case "file":
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	default:
+		return "", fmt.Errorf("unknown reference kind %q, expected env or file", kind)
+	}
+}
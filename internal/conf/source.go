@@ -0,0 +1,231 @@
+package conf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding Bootstrap fields.
+// The rest of the name is the path of the field in upper case with dots replaced
+// by underscores, e.g. GOTEMPLATE_DATA_DATABASE_SOURCE overrides data.database.source.
+const EnvPrefix = "GOTEMPLATE_"
+
+const durationName protoreflect.FullName = "google.protobuf.Duration"
+
+// Override is a Bootstrap field set by an OverrideSource.
+type Override struct {
+	// Key is the path of the field, e.g. data.database.source.
+	Key string
+	// Origin is where the value comes from, e.g. the environment variable.
+	Origin string
+}
+
+// OverrideSource is a config.Source of values for single Bootstrap fields.
+// Added after the file source, its values take precedence over the files.
+type OverrideSource struct {
+	name      string
+	values    map[string]interface{}
+	overrides []Override
+}
+
+var _ config.Source = (*OverrideSource)(nil)
+
+// NewEnvSource creates a source from the environment variables starting with prefix.
+// Variables that do not name a Bootstrap field are ignored.
+func NewEnvSource(prefix string) (*OverrideSource, error) {
+	paths := make(map[string]string)
+	fields := bootstrapFields()
+	for path := range fields {
+		paths[prefix+strings.ToUpper(strings.ReplaceAll(path, ".", "_"))] = path
+	}
+
+	s := newOverrideSource("env")
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		path, ok := paths[name]
+		if !ok {
+			continue
+		}
+		if err := s.set(path, fields[path], value, name); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// NewSetSource creates a source from key=value pairs, where key is the path of
+// a Bootstrap field, e.g. server.http.addr=:8080. Unknown keys are an error.
+func NewSetSource(pairs []string) (*OverrideSource, error) {
+	fields := bootstrapFields()
+
+	s := newOverrideSource("set")
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid override %q, expected key=value", pair)
+		}
+		fd, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("invalid override %q: unknown config key %q", pair, key)
+		}
+		if err := s.set(key, fd, value, "--set"); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func newOverrideSource(name string) *OverrideSource {
+	return &OverrideSource{
+		name:   name,
+		values: make(map[string]interface{}),
+	}
+}
+
+// Overrides returns the fields set by the source, sorted by key.
+func (s *OverrideSource) Overrides() []Override {
+	overrides := append([]Override(nil), s.overrides...)
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Key < overrides[j].Key
+	})
+	return overrides
+}
+
+// Load implements config.Source.
+func (s *OverrideSource) Load() ([]*config.KeyValue, error) {
+	b, err := json.Marshal(s.values)
+	if err != nil {
+		return nil, err
+	}
+	return []*config.KeyValue{{
+		Key:    s.name,
+		Value:  b,
+		Format: "json",
+	}}, nil
+}
+
+// Watch implements config.Source. The values of the source never change.
+func (s *OverrideSource) Watch() (config.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &staticWatcher{ctx: ctx, cancel: cancel}, nil
+}
+
+// set parses value as the type of fd and stores it under path.
+func (s *OverrideSource) set(path string, fd protoreflect.FieldDescriptor, value, origin string) error {
+	v, err := parseFieldValue(fd, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s (%s): %w", path, origin, err)
+	}
+
+	keys := strings.Split(path, ".")
+	m := s.values
+	for _, k := range keys[:len(keys)-1] {
+		sub, ok := m[k].(map[string]interface{})
+		if !ok {
+			sub = make(map[string]interface{})
+			m[k] = sub
+		}
+		m = sub
+	}
+	if _, ok := m[keys[len(keys)-1]]; !ok {
+		s.overrides = append(s.overrides, Override{Key: path, Origin: origin})
+	}
+	m[keys[len(keys)-1]] = v
+	return nil
+}
+
+// staticWatcher blocks until it is stopped.
+type staticWatcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Next implements config.Watcher.
+func (w *staticWatcher) Next() ([]*config.KeyValue, error) {
+	<-w.ctx.Done()
+	return nil, w.ctx.Err()
+}
+
+// Stop implements config.Watcher.
+func (w *staticWatcher) Stop() error {
+	w.cancel()
+	return nil
+}
+
+// bootstrapFields returns the fields of Bootstrap that hold a value, keyed by their path.
+// Durations are values, and repeated scalars are set from a comma separated list.
+func bootstrapFields() map[string]protoreflect.FieldDescriptor {
+	fields := make(map[string]protoreflect.FieldDescriptor)
+	var walk func(md protoreflect.MessageDescriptor, prefix string)
+	walk = func(md protoreflect.MessageDescriptor, prefix string) {
+		for i := 0; i < md.Fields().Len(); i++ {
+			fd := md.Fields().Get(i)
+			path := prefix + string(fd.Name())
+			switch {
+			case fd.IsMap():
+				continue
+			case fd.Kind() == protoreflect.MessageKind && !isDuration(fd):
+				if !fd.IsList() {
+					walk(fd.Message(), path+".")
+				}
+			default:
+				fields[path] = fd
+			}
+		}
+	}
+	walk((*Bootstrap)(nil).ProtoReflect().Descriptor(), "")
+	return fields
+}
+
+// parseFieldValue converts value into the JSON value of fd.
+func parseFieldValue(fd protoreflect.FieldDescriptor, value string) (interface{}, error) {
+	if fd.IsList() {
+		var list []interface{}
+		for _, item := range strings.Split(value, ",") {
+			v, err := parseScalar(fd, strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+	return parseScalar(fd, value)
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, value string) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.ParseBool(value)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return strconv.ParseInt(value, 10, 32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.ParseInt(value, 10, 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return strconv.ParseUint(value, 10, 32)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.ParseUint(value, 10, 64)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return strconv.ParseFloat(value, 64)
+	case protoreflect.MessageKind:
+		// durations are written in seconds, e.g. 1.5s, as in the config files
+		if _, err := strconv.ParseFloat(strings.TrimSuffix(value, "s"), 64); err != nil || !strings.HasSuffix(value, "s") {
+			return nil, fmt.Errorf("invalid duration %q, expected seconds such as 1.5s", value)
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+func isDuration(fd protoreflect.FieldDescriptor) bool {
+	return fd.Message() != nil && fd.Message().FullName() == durationName
+}
@@ -0,0 +1,186 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+)
+
+const sourceTestConfig = `
+server:
+  http:
+    addr: 0.0.0.0:8000
+    timeout: 1s
+  grpc:
+    addr: 0.0.0.0:9000
+data:
+  database:
+    driver: sqlite
+    source: file.db
+    max_open_conns: 10
+metrics:
+  request_buckets: [0.1, 1]
+`
+
+// loadSources loads the config file content overridden by the GOTEMPLATE_ environment
+// variables, then by pairs, as the server does, and returns the result and the overrides.
+func loadSources(t *testing.T, content string, pairs ...string) (*Bootstrap, []Override) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env, err := NewEnvSource(EnvPrefix)
+	if err != nil {
+		t.Fatalf("NewEnvSource() error = %v", err)
+	}
+	set, err := NewSetSource(pairs)
+	if err != nil {
+		t.Fatalf("NewSetSource() error = %v", err)
+	}
+
+	c := config.New(config.WithSource(file.NewSource(dir), env, set))
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var bc Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	return &bc, append(env.Overrides(), set.Overrides()...)
+}
+
+func TestSourcePrecedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		pairs []string
+		// check fails the test unless bc has the expected values
+		check         func(t *testing.T, bc *Bootstrap)
+		wantOverrides []Override
+	}{
+		{
+			name: "file",
+			check: func(t *testing.T, bc *Bootstrap) {
+				assertEqual(t, "server.http.addr", bc.GetServer().GetHttp().GetAddr(), "0.0.0.0:8000")
+				assertEqual(t, "data.database.source", bc.GetData().GetDatabase().GetSource(), "file.db")
+			},
+		},
+		{
+			name: "environment over file",
+			env:  map[string]string{"GOTEMPLATE_DATA_DATABASE_SOURCE": "env.db", "GOTEMPLATE_SERVER_HTTP_TIMEOUT": "2.5s"},
+			check: func(t *testing.T, bc *Bootstrap) {
+				assertEqual(t, "data.database.source", bc.GetData().GetDatabase().GetSource(), "env.db")
+				assertEqual(t, "server.http.timeout", bc.GetServer().GetHttp().GetTimeout().AsDuration(), 2500*time.Millisecond)
+				// the other fields of the file are kept
+				assertEqual(t, "data.database.driver", bc.GetData().GetDatabase().GetDriver(), "sqlite")
+				assertEqual(t, "server.http.addr", bc.GetServer().GetHttp().GetAddr(), "0.0.0.0:8000")
+			},
+			wantOverrides: []Override{
+				{Key: "data.database.source", Origin: "GOTEMPLATE_DATA_DATABASE_SOURCE"},
+				{Key: "server.http.timeout", Origin: "GOTEMPLATE_SERVER_HTTP_TIMEOUT"},
+			},
+		},
+		{
+			name:  "set over environment",
+			env:   map[string]string{"GOTEMPLATE_DATA_DATABASE_SOURCE": "env.db"},
+			pairs: []string{"data.database.source=set.db"},
+			check: func(t *testing.T, bc *Bootstrap) {
+				assertEqual(t, "data.database.source", bc.GetData().GetDatabase().GetSource(), "set.db")
+			},
+			wantOverrides: []Override{
+				{Key: "data.database.source", Origin: "GOTEMPLATE_DATA_DATABASE_SOURCE"},
+				{Key: "data.database.source", Origin: "--set"},
+			},
+		},
+		{
+			name:  "last set wins",
+			pairs: []string{"server.grpc.addr=:1", "server.grpc.addr=:2"},
+			check: func(t *testing.T, bc *Bootstrap) {
+				assertEqual(t, "server.grpc.addr", bc.GetServer().GetGrpc().GetAddr(), ":2")
+			},
+			wantOverrides: []Override{{Key: "server.grpc.addr", Origin: "--set"}},
+		},
+		{
+			name:  "typed values",
+			env:   map[string]string{"GOTEMPLATE_METRICS_DISABLE": "true"},
+			pairs: []string{"data.database.max_open_conns=3", "metrics.request_buckets=0.25, 0.5,2"},
+			check: func(t *testing.T, bc *Bootstrap) {
+				assertEqual(t, "metrics.disable", bc.GetMetrics().GetDisable(), true)
+				assertEqual(t, "data.database.max_open_conns", bc.GetData().GetDatabase().GetMaxOpenConns(), int32(3))
+				if got := bc.GetMetrics().GetRequestBuckets(); !slices.Equal(got, []float64{0.25, 0.5, 2}) {
+					t.Errorf("metrics.request_buckets = %v, want [0.25 0.5 2]", got)
+				}
+			},
+			wantOverrides: []Override{
+				{Key: "metrics.disable", Origin: "GOTEMPLATE_METRICS_DISABLE"},
+				{Key: "data.database.max_open_conns", Origin: "--set"},
+				{Key: "metrics.request_buckets", Origin: "--set"},
+			},
+		},
+		{
+			name: "unknown environment variables are ignored",
+			env:  map[string]string{"GOTEMPLATE_DATA_DATABASE_SOURCES": "x", "DATA_DATABASE_SOURCE": "x"},
+			check: func(t *testing.T, bc *Bootstrap) {
+				assertEqual(t, "data.database.source", bc.GetData().GetDatabase().GetSource(), "file.db")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			bc, overrides := loadSources(t, sourceTestConfig, tt.pairs...)
+			tt.check(t, bc)
+			if !slices.Equal(overrides, tt.wantOverrides) {
+				t.Errorf("overrides = %v, want %v", overrides, tt.wantOverrides)
+			}
+		})
+	}
+}
+
+func TestOverrideSourceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		pairs   []string
+		wantErr string
+	}{
+		{name: "not a pair", pairs: []string{"server.http.addr"}, wantErr: "expected key=value"},
+		{name: "unknown key", pairs: []string{"server.http.port=80"}, wantErr: `unknown config key "server.http.port"`},
+		{name: "message key", pairs: []string{"server.http=x"}, wantErr: `unknown config key "server.http"`},
+		{name: "bad integer", pairs: []string{"data.database.max_open_conns=many"}, wantErr: "invalid value for data.database.max_open_conns (--set)"},
+		{name: "bad duration", pairs: []string{"server.http.timeout=1m"}, wantErr: "expected seconds such as 1.5s"},
+		{name: "bad bool from the environment", env: map[string]string{"GOTEMPLATE_METRICS_DISABLE": "maybe"},
+			wantErr: "invalid value for metrics.disable (GOTEMPLATE_METRICS_DISABLE)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := NewEnvSource(EnvPrefix)
+			if err == nil {
+				_, err = NewSetSource(tt.pairs)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func assertEqual[T comparable](t *testing.T, key string, got, want T) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s = %v, want %v", key, got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package conf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ValidationError lists the fields of a Bootstrap that break the validation rules of conf.proto.
+type ValidationError struct {
+	// Violations are the broken rules, e.g. "data.database.source: value length must be at least 1 runes".
+	Violations []string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Violations, "; ")
+}
+
+// fieldError is implemented by the validation errors generated by protoc-gen-validate.
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// multiError is implemented by the multi errors generated by protoc-gen-validate.
+type multiError interface {
+	AllErrors() []error
+}
+
+// Validate checks bc against the validation rules of conf.proto and reports every broken rule.
+func Validate(bc *Bootstrap) error {
+	var all []string
+	if err := bc.ValidateAll(); err != nil {
+		all = violations(err, bc.ProtoReflect().Descriptor(), "")
+	}
+	// regular expressions are not checked by protoc-gen-validate
+	for i, p := range bc.GetLog().GetRedaction().GetPatterns() {
+		if _, err := regexp.Compile(p); err != nil {
+			all = append(all, fmt.Sprintf("log.redaction.patterns[%d]: %v", i, err))
+		}
+	}
+	// the admin API changes the server, so it is never served without authentication
+	if bc.GetMetrics().GetAdmin() && bc.GetMetrics().GetBasicAuth() == nil {
+		all = append(all, "metrics.admin: requires metrics.basic_auth")
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: all}
+}
+
+// violations flattens the validation errors of a message of type md into "key: reason" strings.
+func violations(err error, md protoreflect.MessageDescriptor, prefix string) []string {
+	if multi, ok := err.(multiError); ok {
+		var all []string
+		for _, err := range multi.AllErrors() {
+			all = append(all, violations(err, md, prefix)...)
+		}
+		return all
+	}
+
+	fe, ok := err.(fieldError)
+	if !ok {
+		return []string{strings.TrimSuffix(prefix, ".") + ": " + err.Error()}
+	}
+
+	// repeated fields are reported as Name[index]
+	goName, index, _ := strings.Cut(fe.Field(), "[")
+	fd := fieldByGoName(md, goName)
+	key := prefix + goName
+	if fd != nil {
+		key = prefix + string(fd.Name())
+	}
+	if index != "" {
+		key += "[" + index
+	}
+
+	cause := fe.Cause()
+	if _, ok := cause.(multiError); !ok {
+		if _, ok := cause.(fieldError); !ok {
+			return []string{key + ": " + fe.Reason()}
+		}
+	}
+	if fd == nil || fd.Message() == nil {
+		return []string{key + ": " + cause.Error()}
+	}
+	return violations(cause, fd.Message(), key+".")
+}
+
+// fieldByGoName returns the field of md whose generated Go name is name, e.g. MaxOpenConns.
+func fieldByGoName(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		var goName strings.Builder
+		for _, part := range strings.Split(string(fd.Name()), "_") {
+			if part != "" {
+				goName.WriteString(strings.ToUpper(part[:1]) + part[1:])
+			}
+		}
+		if goName.String() == name {
+			return fd
+		}
+	}
+	return nil
+}
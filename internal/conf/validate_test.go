@@ -0,0 +1,123 @@
+package conf
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// validBootstrap returns the smallest config that passes Validate.
+func validBootstrap() *Bootstrap {
+	return &Bootstrap{
+		Server: &Server{
+			Http: &Server_HTTP{Addr: ":8000"},
+			Grpc: &Server_GRPC{Addr: ":9000"},
+		},
+		Data: &Data{Database: &Data_Database{Driver: "sqlite", Source: "file.db"}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		mutate         func(bc *Bootstrap)
+		wantViolations []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(bc *Bootstrap) {},
+		},
+		{
+			name: "valid optional fields",
+			mutate: func(bc *Bootstrap) {
+				bc.Server.Http.Addr = "/run/app.sock"
+				bc.Server.Http.Timeout = durationpb.New(time.Second)
+				bc.Metrics = &Metrics{Namespace: "app", RequestBuckets: []float64{0.1, 1}, BasicAuth: &Metrics_BasicAuth{Username: "u", Password: "p"}, Admin: true}
+				bc.Log = &Log{Redaction: &Log_Redaction{Patterns: []string{`\d{4}`}}}
+			},
+		},
+		{
+			name:           "required messages",
+			mutate:         func(bc *Bootstrap) { bc.Server.Grpc, bc.Data = nil, nil },
+			wantViolations: []string{"server.grpc: value is required", "data: value is required"},
+		},
+		{
+			name: "every violation of a message",
+			mutate: func(bc *Bootstrap) {
+				bc.Data.Database.Driver = "oracle"
+				bc.Data.Database.Source = ""
+				bc.Data.Database.MaxOpenConns = -1
+			},
+			wantViolations: []string{
+				"data.database.driver: value must be in list [postgres postgresql mysql sqlite sqlite3 sqlserver mssql]",
+				"data.database.source: value length must be at least 1 runes",
+				"data.database.max_open_conns: value must be greater than or equal to 0",
+			},
+		},
+		{
+			name: "address and duration",
+			mutate: func(bc *Bootstrap) {
+				bc.Server.Http.Addr = "8000"
+				bc.Server.Http.Timeout = durationpb.New(0)
+			},
+			wantViolations: []string{
+				`server.http.addr: value does not match regex pattern "^((\\[[0-9a-fA-F:.]+\\]|[^:\\[\\]]*):[0-9]{1,5}|/.+)$"`,
+				"server.http.timeout: value must be greater than 0s",
+			},
+		},
+		{
+			name:   "repeated field",
+			mutate: func(bc *Bootstrap) { bc.Metrics = &Metrics{RequestBuckets: []float64{0.1, -1}} },
+			wantViolations: []string{
+				"metrics.request_buckets[1]: value must be greater than 0",
+			},
+		},
+		{
+			name:   "nested message",
+			mutate: func(bc *Bootstrap) { bc.Metrics = &Metrics{BasicAuth: &Metrics_BasicAuth{Username: "u"}} },
+			wantViolations: []string{
+				"metrics.basic_auth.password: value length must be at least 1 runes",
+			},
+		},
+		{
+			name:           "admin without authentication",
+			mutate:         func(bc *Bootstrap) { bc.Metrics = &Metrics{Admin: true} },
+			wantViolations: []string{"metrics.admin: requires metrics.basic_auth"},
+		},
+		{
+			name:   "redaction pattern",
+			mutate: func(bc *Bootstrap) { bc.Log = &Log{Redaction: &Log_Redaction{Patterns: []string{"ok", "("}}} },
+			wantViolations: []string{
+				"log.redaction.patterns[1]: error parsing regexp: missing closing ): `(`",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := validBootstrap()
+			tt.mutate(bc)
+			err := Validate(bc)
+			if tt.wantViolations == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			if !slices.Equal(verr.Violations, tt.wantViolations) {
+				t.Errorf("Violations =\n%s\nwant\n%s", strings.Join(verr.Violations, "\n"), strings.Join(tt.wantViolations, "\n"))
+			}
+			if !strings.HasPrefix(err.Error(), "invalid config: ") {
+				t.Errorf("Error() = %q, want the invalid config prefix", err.Error())
+			}
+		})
+	}
+}
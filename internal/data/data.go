@@ -1,13 +1,18 @@
 package data
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
-	"os"
-	"path/filepath"
+	"sync/atomic"
+	"time"
 
 	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	// Database drivers
 	"gorm.io/driver/mysql"
@@ -20,101 +25,320 @@ import (
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewGreeterRepo)
+var ProviderSet = wire.NewSet(NewData, NewTransaction, NewHealthRegistry, NewGreeterRepo)
 
 // Data .
 type Data struct {
-	gorm *gorm.DB
+	gorm     *gorm.DB
+	replicas *replicaSet
+	pool     *atomic.Pointer[conf.Data_Database]
+	redis    *redis.Client
+	cache    *conf.Data_Cache
 }
 
-// GetDatabaseDialector get database dialector from driver and dsn
-func GetDatabaseDialector(driver, dsn string) (gorm.Dialector, error) {
+// Dialect returns the canonical dialect name of a database driver,
+// one of postgres, mysql, sqlite or sqlserver.
+func Dialect(driver string) (string, error) {
 	switch driver {
 	case "postgres", "postgresql":
+		return "postgres", nil
+	case "mysql":
+		return "mysql", nil
+	case "sqlite", "sqlite3":
+		return "sqlite", nil
+	case "sqlserver", "mssql":
+		return "sqlserver", nil
+	default:
+		return "", fmt.Errorf("unsupported database driver: %s", driver)
+	}
+}
+
+// GetDatabaseDialector get database dialector from driver and dsn
+func GetDatabaseDialector(driver, dsn string) (gorm.Dialector, error) {
+	dialect, err := Dialect(driver)
+	if err != nil {
+		return nil, err
+	}
+	switch dialect {
+	case "postgres":
 		return postgres.Open(dsn), nil
 	case "mysql":
 		return mysql.Open(dsn), nil
-	case "sqlite", "sqlite3":
+	case "sqlite":
 		return sqlite.Open(dsn), nil
-	case "sqlserver", "mssql":
-		return sqlserver.Open(dsn), nil
 	default:
-		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+		return sqlserver.Open(dsn), nil
 	}
 }
 
-// NewData .
-func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
-	// dsn := c.Database.Source
-	// driver := c.Database.Driver
-
-	// if dsn == "" {
-	// 	return nil, func() {}, errors.New("database source is required")
-	// }
-	// if driver == "" {
-	// 	return nil, func() {}, errors.New("database driver is required")
-	// }
-
-	// dialector, err := GetDatabaseDialector(driver, dsn)
-	// if err != nil {
-	// 	return nil, func() {}, fmt.Errorf("failed to get database dialector: %w", err)
-	// }
-
-	// gormConfig := gorm.Config{
-	// 	Logger:                 NewLogger(logger),
-	// 	SkipDefaultTransaction: true,
-	// 	CreateBatchSize:        3_000,
-	// }
-
-	// gormdb, err := gorm.Open(dialector, &gormConfig)
-	// if err != nil {
-	// 	return nil, func() {}, fmt.Errorf("failed to connect to database (%s): %w", driver, err)
-	// }
-
-	// rawdb, _ := gormdb.DB()
-	// rawdb.SetMaxOpenConns(200)
-	// rawdb.SetConnMaxLifetime(time.Hour)
-	// rawdb.SetConnMaxIdleTime(time.Minute * 5)
-	// rawdb.SetMaxIdleConns(10)
-
-	// db := &Data{
-	// 	gorm: gormdb,
-	// }
-
-	// return db, func() {}, nil
-	return nil, func() {}, nil
+// Default database connection pool settings, used when conf.Data.Database leaves them unset.
+const (
+	defaultMaxOpenConns        = 200
+	defaultMaxIdleConns        = 10
+	defaultConnMaxLifetime     = time.Hour
+	defaultConnMaxIdleTime     = 5 * time.Minute
+	defaultConnectTimeout      = 5 * time.Second
+	defaultConnectRetryBackoff = time.Second
+	maxConnectRetryBackoff     = 30 * time.Second
+)
+
+// poolKeys are the config keys of the connection pool settings, which are applied on reload.
+var poolKeys = []string{
+	"data.database.max_open_conns",
+	"data.database.max_idle_conns",
+	"data.database.conn_max_lifetime",
+	"data.database.conn_max_idle_time",
 }
 
-// GetDB return gorm db instance
-func (d *Data) GetDB() *gorm.DB {
-	return d.gorm
+// NewData connects to the databases and Redis configured in c.
+// When reloader is not nil, the connection pool settings are applied on reload.
+// When metricer is not nil, the statements and the connection pools are measured.
+func NewData(c *conf.Data, reloader *conf.Reloader, metricer metrics.Metricer, logger log.Logger) (*Data, func(), error) {
+	if c == nil || c.Database == nil {
+		return nil, func() {}, errors.New("database configuration is required")
+	}
+
+	dsn := c.Database.Source
+	driver := c.Database.Driver
+
+	if dsn == "" {
+		return nil, func() {}, errors.New("database source is required")
+	}
+	if driver == "" {
+		return nil, func() {}, errors.New("database driver is required")
+	}
+
+	gormConfig := gorm.Config{
+		Logger:                 NewLogger(logger),
+		SkipDefaultTransaction: true,
+		CreateBatchSize:        3_000,
+		DisableAutomaticPing:   true,
+		Plugins:                map[string]gorm.Plugin{tracingPlugin{}.Name(): tracingPlugin{}},
+	}
+	if metricer != nil {
+		gormConfig.Plugins[metricsPlugin{}.Name()] = metricsPlugin{metricer: metricer}
+	}
+
+	gormdb, err := connectWithRetry(c.Database, &gormConfig, log.NewHelper(logger))
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	rawdb, err := gormdb.DB()
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("failed to get database connection pool: %w", err)
+	}
+	pool := new(atomic.Pointer[conf.Data_Database])
+	pool.Store(c.Database)
+	configurePool(rawdb, c.Database)
+	if metricer != nil {
+		if err := metricer.RegisterDBStats("primary", rawdb); err != nil {
+			_ = rawdb.Close()
+			return nil, func() {}, err
+		}
+	}
+
+	replicas, err := openReplicas(c.Database, pool, &gormConfig, metricer, log.NewHelper(logger))
+	if err != nil {
+		_ = rawdb.Close()
+		return nil, func() {}, err
+	}
+
+	rdb, err := newRedis(c.Redis)
+	if err != nil {
+		if replicas != nil {
+			replicas.close()
+		}
+		_ = rawdb.Close()
+		return nil, func() {}, err
+	}
+
+	db := &Data{
+		gorm:     gormdb,
+		replicas: replicas,
+		pool:     pool,
+		redis:    rdb,
+		cache:    c.Cache,
+	}
+
+	if reloader != nil {
+		reloader.Register(func(bc *conf.Bootstrap) error {
+			return db.reconfigurePool(bc.GetData().GetDatabase())
+		}, poolKeys...)
+	}
+
+	cleanup := func() {
+		log.NewHelper(logger).Info("closing the data resources")
+		if rdb != nil {
+			if err := rdb.Close(); err != nil {
+				log.NewHelper(logger).Errorf("failed to close redis client: %v", err)
+			}
+		}
+		if replicas != nil {
+			replicas.close()
+		}
+		if err := rawdb.Close(); err != nil {
+			log.NewHelper(logger).Errorf("failed to close database connection pool: %v", err)
+		}
+	}
+
+	return db, cleanup, nil
 }
 
-// ExecuteSQLMigration execute sql migration from migrationsFolder
-func (db *Data) ExecuteSQLMigration(migrationsFolder string) error {
-	err := filepath.Walk(migrationsFolder, func(path string, info os.FileInfo, err error) error {
+// openReplicas prepares the read replicas of c and starts their health checks.
+// Replicas are configured with the pool settings in pool when they are opened, and their
+// connection pool stats are registered with metricer, when it is not nil, as replica-<index>.
+// It returns nil when no replica is configured.
+func openReplicas(c *conf.Data_Database, pool *atomic.Pointer[conf.Data_Database], gormConfig *gorm.Config, metricer metrics.Metricer, logger *log.Helper) (*replicaSet, error) {
+	if len(c.Replicas) == 0 {
+		return nil, nil
+	}
+
+	openers := make([]func() (*gorm.DB, error), 0, len(c.Replicas))
+	for i, dsn := range c.Replicas {
+		dialector, err := GetDatabaseDialector(c.Driver, dsn)
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("Failed to process migration file: %s", path))
-		}
-		if info.IsDir() {
-			return nil
+			return nil, fmt.Errorf("failed to get database dialector for read replica-%d: %w", i, err)
 		}
+		openers = append(openers, func() (*gorm.DB, error) {
+			db, err := gorm.Open(dialector, gormConfig)
+			if err != nil {
+				return nil, err
+			}
+			rawdb, err := db.DB()
+			if err != nil {
+				return nil, err
+			}
+			configurePool(rawdb, pool.Load())
+			if metricer != nil {
+				if err := metricer.RegisterDBStats(fmt.Sprintf("replica-%d", i), rawdb); err != nil {
+					_ = rawdb.Close()
+					return nil, err
+				}
+			}
+			return db, nil
+		})
+	}
+
+	replicas := newReplicaSet(openers, durationOrDefault(c.ConnectTimeout, defaultConnectTimeout), logger)
+	replicas.watch(durationOrDefault(c.ReplicaHealthCheckInterval, defaultReplicaHealthCheckInterval))
+	return replicas, nil
+}
+
+// connectWithRetry opens the database and pings it, retrying with an exponential backoff
+// as many times as c.ConnectRetries allows.
+func connectWithRetry(c *conf.Data_Database, gormConfig *gorm.Config, logger *log.Helper) (*gorm.DB, error) {
+	timeout := durationOrDefault(c.ConnectTimeout, defaultConnectTimeout)
+	backoff := durationOrDefault(c.ConnectRetryBackoff, defaultConnectRetryBackoff)
+	attempts := int(c.ConnectRetries) + 1
 
-		if filepath.Ext(path) != ".sql" {
-			return nil
+	var err error
+	for attempt := 1; ; attempt++ {
+		var gormdb *gorm.DB
+		gormdb, err = connect(c.Driver, c.Source, gormConfig, timeout)
+		if err == nil {
+			return gormdb, nil
+		}
+		if attempt >= attempts {
+			break
 		}
 
-		// #nosec G304 - path is validated above
-		fileContent, readErr := os.ReadFile(path)
-		if readErr != nil {
-			return errors.Wrap(readErr, fmt.Sprintf("Error reading SQL file: %s", path))
+		logger.Warnf("failed to connect to database (%s), attempt %d/%d, retrying in %s: %v", c.Driver, attempt, attempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxConnectRetryBackoff {
+			backoff = maxConnectRetryBackoff
 		}
+	}
+	return nil, fmt.Errorf("failed to connect to database (%s): %w", c.Driver, err)
+}
 
-		execErr := db.gorm.Exec(string(fileContent)).Error
-		if execErr != nil {
-			return errors.Wrap(execErr, fmt.Sprintf("Error executing SQL script: %s", path))
+// connect opens the database and checks it is reachable within timeout.
+func connect(driver, dsn string, gormConfig *gorm.Config, timeout time.Duration) (*gorm.DB, error) {
+	dialector, err := GetDatabaseDialector(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database dialector: %w", err)
+	}
+
+	gormdb, err := gorm.Open(dialector, gormConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ping(gormdb, timeout); err != nil {
+		if rawdb, dbErr := gormdb.DB(); dbErr == nil {
+			_ = rawdb.Close()
 		}
-		return nil
-	})
-	return err
+		return nil, err
+	}
+	return gormdb, nil
+}
+
+// configurePool applies the connection pool settings of c to db.
+func configurePool(db *sql.DB, c *conf.Data_Database) {
+	maxOpenConns := defaultMaxOpenConns
+	if c.MaxOpenConns > 0 {
+		maxOpenConns = int(c.MaxOpenConns)
+	}
+	maxIdleConns := defaultMaxIdleConns
+	if c.MaxIdleConns > 0 {
+		maxIdleConns = int(c.MaxIdleConns)
+	}
+
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(durationOrDefault(c.ConnMaxLifetime, defaultConnMaxLifetime))
+	db.SetConnMaxIdleTime(durationOrDefault(c.ConnMaxIdleTime, defaultConnMaxIdleTime))
+}
+
+// reconfigurePool applies the connection pool settings of c to the primary and the read replicas.
+func (d *Data) reconfigurePool(c *conf.Data_Database) error {
+	rawdb, err := d.gorm.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection pool: %w", err)
+	}
+	d.pool.Store(c)
+	configurePool(rawdb, c)
+	if d.replicas != nil {
+		d.replicas.configure(func(db *sql.DB) { configurePool(db, c) })
+	}
+	return nil
+}
+
+// durationOrDefault returns d, or def when d is unset or not positive.
+func durationOrDefault(d *durationpb.Duration, def time.Duration) time.Duration {
+	if d == nil || d.AsDuration() <= 0 {
+		return def
+	}
+	return d.AsDuration()
+}
+
+// GetDB return gorm db instance
+func (d *Data) GetDB() *gorm.DB {
+	return d.gorm
+}
+
+// DB returns the primary database bound to ctx, to be used for writes.
+// Inside InTx it returns the transaction.
+func (d *Data) DB(ctx context.Context) *gorm.DB {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return d.gorm.WithContext(ctx)
+}
+
+// ReadDB returns a healthy read replica bound to ctx, to be used for reads.
+// It falls back to the primary when no replica is configured or healthy,
+// or when ctx was created with WithPrimary, and returns the transaction inside InTx.
+func (d *Data) ReadDB(ctx context.Context) *gorm.DB {
+	if _, ok := txFromContext(ctx); ok {
+		return d.DB(ctx)
+	}
+	if d.replicas == nil || isPrimaryForced(ctx) {
+		return d.DB(ctx)
+	}
+	if db := d.replicas.pick(); db != nil {
+		return db.WithContext(ctx)
+	}
+	return d.DB(ctx)
 }
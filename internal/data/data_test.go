@@ -0,0 +1,53 @@
+package data
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// testLogger discards the logs of the tests.
+var testLogger = log.NewStdLogger(io.Discard)
+
+// newTestData returns a Data on an in-memory sqlite database, migrated with the migrations
+// of the repository, and with the Redis of redis when it is not nil.
+func newTestData(t *testing.T, redis *conf.Data_Redis) *Data {
+	t.Helper()
+	d := openTestData(t, redis)
+	migrateTestData(t, d)
+	return d
+}
+
+// openTestData returns a Data on an empty in-memory sqlite database, closed when the test ends.
+func openTestData(t *testing.T, redis *conf.Data_Redis) *Data {
+	t.Helper()
+	return openTestDatabase(t, &conf.Data_Database{
+		Driver: "sqlite",
+		Source: "file::memory:",
+		// every connection to file::memory: opens its own empty database
+		MaxOpenConns: 1,
+	}, redis)
+}
+
+// openTestDatabase returns a Data on the database of c, closed when the test ends.
+func openTestDatabase(t *testing.T, c *conf.Data_Database, redis *conf.Data_Redis) *Data {
+	t.Helper()
+	d, cleanup, err := NewData(&conf.Data{Database: c, Redis: redis}, nil, nil, testLogger)
+	if err != nil {
+		t.Fatalf("NewData() error = %v", err)
+	}
+	t.Cleanup(cleanup)
+	return d
+}
+
+// migrateTestData applies the migrations of the repository to the database of d.
+func migrateTestData(t *testing.T, d *Data) {
+	t.Helper()
+	if _, err := NewMigrator(d, "../../migrations", testLogger).Up(context.Background(), 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+}
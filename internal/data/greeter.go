@@ -2,46 +2,127 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/adam-xu-mantle/go-template/internal/biz"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
 )
 
+// Greeter is the database model of biz.Greeter.
+type Greeter struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	Hello     string    `gorm:"column:hello;size:255;not null;index:idx_greeters_hello"`
+	CreatedAt time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name of the Greeter model.
+func (Greeter) TableName() string {
+	return "greeters"
+}
+
+func (g *Greeter) toBiz() *biz.Greeter {
+	return &biz.Greeter{
+		ID:        g.ID,
+		Hello:     g.Hello,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}
+}
+
 type greeterRepo struct {
 	data *Data
 	log  *log.Helper
 }
 
-// NewGreeterRepo .
+// NewGreeterRepo creates the greeter repo, cached in Redis when Redis is configured
+// and the cache is not disabled.
 func NewGreeterRepo(data *Data, logger log.Logger) biz.GreeterRepo {
-	return &greeterRepo{
+	repo := &greeterRepo{
 		data: data,
 		log:  log.NewHelper(logger),
 	}
+	if data.redis == nil || data.cache.GetDisable() {
+		return repo
+	}
+	return newCachedGreeterRepo(
+		repo,
+		data.redis,
+		durationOrDefault(data.cache.GetItemTtl(), defaultCacheItemTTL),
+		durationOrDefault(data.cache.GetListTtl(), defaultCacheListTTL),
+		logger,
+	)
 }
 
 // Save implements biz.GreeterRepo.
 func (r *greeterRepo) Save(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
-	return g, nil
+	m := &Greeter{Hello: g.Hello}
+	if err := r.data.DB(ctx).Create(m).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to save greeter")
+	}
+	return m.toBiz(), nil
 }
 
 // Update implements biz.GreeterRepo.
 func (r *greeterRepo) Update(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
-	return g, nil
+	// no greeter has an ID below 1, and GORM refuses an update without the condition of the zero ID
+	if g.ID <= 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	res := r.data.DB(ctx).
+		Model(&Greeter{ID: g.ID}).
+		Updates(map[string]interface{}{
+			"hello":      g.Hello,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return nil, errors.Wrapf(res.Error, "failed to update greeter %d", g.ID)
+	}
+	if res.RowsAffected == 0 {
+		return nil, biz.ErrUserNotFound
+	}
+	return r.FindByID(WithPrimary(ctx), g.ID)
 }
 
 // FindByID implements biz.GreeterRepo.
-func (r *greeterRepo) FindByID(context.Context, int64) (*biz.Greeter, error) {
-	return nil, nil
+func (r *greeterRepo) FindByID(ctx context.Context, id int64) (*biz.Greeter, error) {
+	var m Greeter
+	err := r.data.ReadDB(ctx).First(&m, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find greeter %d", id)
+	}
+	return m.toBiz(), nil
 }
 
 // ListByHello implements biz.GreeterRepo.
-func (r *greeterRepo) ListByHello(context.Context, string) ([]*biz.Greeter, error) {
-	return nil, nil
+func (r *greeterRepo) ListByHello(ctx context.Context, hello string) ([]*biz.Greeter, error) {
+	var ms []*Greeter
+	err := r.data.ReadDB(ctx).Where("hello = ?", hello).Order("id").Find(&ms).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list greeters by hello")
+	}
+	return toBizGreeters(ms), nil
 }
 
 // ListAll implements biz.GreeterRepo.
-func (r *greeterRepo) ListAll(context.Context) ([]*biz.Greeter, error) {
-	return nil, nil
+func (r *greeterRepo) ListAll(ctx context.Context) ([]*biz.Greeter, error) {
+	var ms []*Greeter
+	if err := r.data.ReadDB(ctx).Order("id").Find(&ms).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list greeters")
+	}
+	return toBizGreeters(ms), nil
+}
+
+func toBizGreeters(ms []*Greeter) []*biz.Greeter {
+	gs := make([]*biz.Greeter, 0, len(ms))
+	for _, m := range ms {
+		gs = append(gs, m.toBiz())
+	}
+	return gs
 }
@@ -0,0 +1,163 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// Default cache TTLs, used when conf.Data.Cache leaves them unset.
+const (
+	defaultCacheItemTTL = 5 * time.Minute
+	defaultCacheListTTL = time.Minute
+)
+
+// cachedGreeterRepo is a cache-aside decorator of a biz.GreeterRepo.
+// FindByID and ListByHello are served from Redis when possible, and Save and Update
+// invalidate the entries they make stale, once their transaction commits when they run in one,
+// so that a read in between cannot cache the values being replaced.
+// Redis errors are logged and fall through to the repo.
+type cachedGreeterRepo struct {
+	biz.GreeterRepo
+
+	redis   *redis.Client
+	itemTTL time.Duration
+	listTTL time.Duration
+	log     *log.Helper
+}
+
+func newCachedGreeterRepo(repo biz.GreeterRepo, rdb *redis.Client, itemTTL, listTTL time.Duration, logger log.Logger) biz.GreeterRepo {
+	return &cachedGreeterRepo{
+		GreeterRepo: repo,
+		redis:       rdb,
+		itemTTL:     itemTTL,
+		listTTL:     listTTL,
+		log:         log.NewHelper(log.With(logger, "module", "data/cache")),
+	}
+}
+
+func greeterIDKey(id int64) string {
+	return fmt.Sprintf("greeter:id:%d", id)
+}
+
+func greeterHelloKey(hello string) string {
+	return "greeter:hello:" + hello
+}
+
+// Save implements biz.GreeterRepo.
+func (r *cachedGreeterRepo) Save(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	saved, err := r.GreeterRepo.Save(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, greeterHelloKey(saved.Hello))
+	return saved, nil
+}
+
+// Update implements biz.GreeterRepo.
+func (r *cachedGreeterRepo) Update(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	keys := []string{greeterIDKey(g.ID), greeterHelloKey(g.Hello)}
+	// the list of the previous hello becomes stale as well
+	if old, err := r.GreeterRepo.FindByID(WithPrimary(ctx), g.ID); err == nil && old.Hello != g.Hello {
+		keys = append(keys, greeterHelloKey(old.Hello))
+	}
+
+	updated, err := r.GreeterRepo.Update(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, keys...)
+	return updated, nil
+}
+
+// FindByID implements biz.GreeterRepo.
+func (r *cachedGreeterRepo) FindByID(ctx context.Context, id int64) (*biz.Greeter, error) {
+	if !r.cacheable(ctx) {
+		return r.GreeterRepo.FindByID(ctx, id)
+	}
+
+	key := greeterIDKey(id)
+	var g biz.Greeter
+	if r.get(ctx, key, &g) {
+		return &g, nil
+	}
+
+	found, err := r.GreeterRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.set(ctx, key, found, r.itemTTL)
+	return found, nil
+}
+
+// ListByHello implements biz.GreeterRepo.
+func (r *cachedGreeterRepo) ListByHello(ctx context.Context, hello string) ([]*biz.Greeter, error) {
+	if !r.cacheable(ctx) {
+		return r.GreeterRepo.ListByHello(ctx, hello)
+	}
+
+	key := greeterHelloKey(hello)
+	var gs []*biz.Greeter
+	if r.get(ctx, key, &gs) {
+		return gs, nil
+	}
+
+	found, err := r.GreeterRepo.ListByHello(ctx, hello)
+	if err != nil {
+		return nil, err
+	}
+	r.set(ctx, key, found, r.listTTL)
+	return found, nil
+}
+
+// cacheable reports whether reads in ctx may use the cache. Reads inside a transaction
+// or forced to the primary must see the latest writes and must not populate the cache.
+func (r *cachedGreeterRepo) cacheable(ctx context.Context) bool {
+	_, inTx := txFromContext(ctx)
+	return !inTx && !isPrimaryForced(ctx)
+}
+
+// get decodes the cached value of key into v and reports whether it was found.
+func (r *cachedGreeterRepo) get(ctx context.Context, key string, v interface{}) bool {
+	b, err := r.redis.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false
+	}
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("failed to read cache key %s: %v", key, err)
+		return false
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		r.log.WithContext(ctx).Warnf("failed to decode cache key %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+// set caches v under key for ttl.
+func (r *cachedGreeterRepo) set(ctx context.Context, key string, v interface{}, ttl time.Duration) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("failed to encode cache key %s: %v", key, err)
+		return
+	}
+	if err := r.redis.Set(ctx, key, b, ttl).Err(); err != nil {
+		r.log.WithContext(ctx).Warnf("failed to write cache key %s: %v", key, err)
+	}
+}
+
+// invalidate removes keys from the cache, once the transaction of ctx commits when there is one.
+func (r *cachedGreeterRepo) invalidate(ctx context.Context, keys ...string) {
+	afterCommit(ctx, func() {
+		if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+			r.log.WithContext(ctx).Warnf("failed to invalidate cache keys %v: %v", keys, err)
+		}
+	})
+}
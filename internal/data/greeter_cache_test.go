@@ -0,0 +1,291 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// newCacheTestRepo returns a cached greeter repo on an in-memory sqlite database and a miniredis,
+// with its Data and the miniredis.
+func newCacheTestRepo(t *testing.T) (biz.GreeterRepo, *Data, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	d := newTestData(t, &conf.Data_Redis{Addr: mr.Addr()})
+	repo := NewGreeterRepo(d, testLogger)
+	if _, ok := repo.(*cachedGreeterRepo); !ok {
+		t.Fatalf("NewGreeterRepo() = %T, want a cached repo", repo)
+	}
+	return repo, d, mr
+}
+
+// setHelloBehindCache changes the hello of the greeter id in the database only, so that reads
+// still returning the previous hello are served by the cache.
+func setHelloBehindCache(t *testing.T, d *Data, id int64, hello string) {
+	t.Helper()
+	if err := d.gorm.Model(&Greeter{ID: id}).Update("hello", hello).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// assertCached fails the test unless every key is cached, or none of them when cached is false.
+func assertCached(t *testing.T, mr *miniredis.Miniredis, cached bool, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		if got := mr.Exists(key); got != cached {
+			t.Errorf("key %s cached = %v, want %v", key, got, cached)
+		}
+	}
+}
+
+func TestCachedGreeterRepoHits(t *testing.T) {
+	ctx := context.Background()
+	repo, d, mr := newCacheTestRepo(t)
+	g := seedGreeters(t, repo, "a")[0]
+
+	if _, err := repo.FindByID(ctx, g.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.ListByHello(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	assertCached(t, mr, true, greeterIDKey(g.ID), greeterHelloKey("a"))
+	if ttl := mr.TTL(greeterIDKey(g.ID)); ttl != defaultCacheItemTTL {
+		t.Errorf("item TTL = %s, want %s", ttl, defaultCacheItemTTL)
+	}
+	if ttl := mr.TTL(greeterHelloKey("a")); ttl != defaultCacheListTTL {
+		t.Errorf("list TTL = %s, want %s", ttl, defaultCacheListTTL)
+	}
+
+	setHelloBehindCache(t, d, g.ID, "changed")
+	found, err := repo.FindByID(ctx, g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Hello != "a" {
+		t.Errorf("FindByID() Hello = %q, want the cached %q", found.Hello, "a")
+	}
+	gs, err := repo.ListByHello(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gs) != 1 || gs[0].ID != g.ID {
+		t.Errorf("ListByHello() = %v, want the cached greeter %d", ids(gs), g.ID)
+	}
+
+	// reads forced to the primary bypass the cache
+	found, err = repo.FindByID(WithPrimary(ctx), g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Hello != "changed" {
+		t.Errorf("FindByID(WithPrimary) Hello = %q, want %q", found.Hello, "changed")
+	}
+
+	// a missing greeter is not cached
+	if _, err := repo.FindByID(ctx, g.ID+1); !errors.Is(err, biz.ErrUserNotFound) {
+		t.Errorf("FindByID() error = %v, want %v", err, biz.ErrUserNotFound)
+	}
+	assertCached(t, mr, false, greeterIDKey(g.ID+1))
+}
+
+func TestCachedGreeterRepoInvalidation(t *testing.T) {
+	ctx := context.Background()
+	repo, _, mr := newCacheTestRepo(t)
+	g := seedGreeters(t, repo, "a")[0]
+
+	// Save invalidates the list of its hello
+	if _, err := repo.ListByHello(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	second := seedGreeters(t, repo, "a")[0]
+	assertCached(t, mr, false, greeterHelloKey("a"))
+	gs, err := repo.ListByHello(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gs) != 2 {
+		t.Errorf("ListByHello() after Save = %v, want %d and %d", ids(gs), g.ID, second.ID)
+	}
+
+	// Update invalidates the greeter, the list of its new hello and the list of its old hello
+	for _, hello := range []string{"a", "b"} {
+		if _, err := repo.ListByHello(ctx, hello); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.FindByID(ctx, g.ID); err != nil {
+		t.Fatal(err)
+	}
+	assertCached(t, mr, true, greeterIDKey(g.ID), greeterHelloKey("a"), greeterHelloKey("b"))
+
+	if _, err := repo.Update(ctx, &biz.Greeter{ID: g.ID, Hello: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	assertCached(t, mr, false, greeterIDKey(g.ID), greeterHelloKey("a"), greeterHelloKey("b"))
+
+	found, err := repo.FindByID(ctx, g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Hello != "b" {
+		t.Errorf("FindByID() after Update Hello = %q, want %q", found.Hello, "b")
+	}
+	for hello, want := range map[string]int{"a": 1, "b": 1} {
+		gs, err := repo.ListByHello(ctx, hello)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(gs) != want {
+			t.Errorf("ListByHello(%q) after Update = %v, want %d greeter", hello, ids(gs), want)
+		}
+	}
+
+	// a failed Update invalidates nothing
+	if _, err := repo.Update(ctx, &biz.Greeter{ID: g.ID + 100, Hello: "b"}); !errors.Is(err, biz.ErrUserNotFound) {
+		t.Fatalf("Update() error = %v, want %v", err, biz.ErrUserNotFound)
+	}
+	assertCached(t, mr, true, greeterIDKey(g.ID), greeterHelloKey("b"))
+}
+
+func TestCachedGreeterRepoInvalidationInTx(t *testing.T) {
+	tests := []struct {
+		name string
+		// fn updates the greeter id to hello in tx, and returns the error of the transaction
+		fn            func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo, id int64) error
+		wantErr       error
+		wantCommitted bool
+	}{
+		{
+			name: "commit",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo, id int64) error {
+				_, err := repo.Update(ctx, &biz.Greeter{ID: id, Hello: "b"})
+				return err
+			},
+			wantCommitted: true,
+		},
+		{
+			name: "rollback",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo, id int64) error {
+				if _, err := repo.Update(ctx, &biz.Greeter{ID: id, Hello: "b"}); err != nil {
+					return err
+				}
+				return errTestRollback
+			},
+			wantErr: errTestRollback,
+		},
+		{
+			name: "savepoint committed",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo, id int64) error {
+				return tx.InTx(ctx, func(ctx context.Context) error {
+					_, err := repo.Update(ctx, &biz.Greeter{ID: id, Hello: "b"})
+					return err
+				})
+			},
+			wantCommitted: true,
+		},
+		{
+			name: "savepoint rolled back",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo, id int64) error {
+				_ = tx.InTx(ctx, func(ctx context.Context) error {
+					if _, err := repo.Update(ctx, &biz.Greeter{ID: id, Hello: "b"}); err != nil {
+						return err
+					}
+					return errTestRollback
+				})
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo, d, mr := newCacheTestRepo(t)
+			tx := NewTransaction(d)
+			g := seedGreeters(t, repo, "a")[0]
+			keys := []string{greeterIDKey(g.ID), greeterHelloKey("a"), greeterHelloKey("b")}
+			for _, hello := range []string{"a", "b"} {
+				if _, err := repo.ListByHello(ctx, hello); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := repo.FindByID(ctx, g.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			err := tx.InTx(ctx, func(ctx context.Context) error {
+				err := tt.fn(ctx, tx, repo, g.ID)
+				// nothing is invalidated before the commit, and reads in the transaction do not cache
+				assertCached(t, mr, true, keys...)
+				if _, err := repo.FindByID(ctx, g.ID+100); !errors.Is(err, biz.ErrUserNotFound) {
+					t.Errorf("FindByID() error = %v, want %v", err, biz.ErrUserNotFound)
+				}
+				assertCached(t, mr, false, greeterIDKey(g.ID+100))
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InTx() error = %v, want %v", err, tt.wantErr)
+			}
+			assertCached(t, mr, !tt.wantCommitted, keys...)
+
+			want := "a"
+			if tt.wantCommitted {
+				want = "b"
+			}
+			found, err := repo.FindByID(ctx, g.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found.Hello != want {
+				t.Errorf("FindByID() Hello = %q, want %q", found.Hello, want)
+			}
+		})
+	}
+}
+
+// TestCachedGreeterRepoNoStaleReadDuringTx reads a greeter from outside a transaction updating it,
+// which would cache its previous value if the cache was invalidated before the commit.
+func TestCachedGreeterRepoNoStaleReadDuringTx(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	// a database file, so that the read outside the transaction has its own connection
+	d := openTestDatabase(t, &conf.Data_Database{
+		Driver: "sqlite",
+		Source: filepath.Join(t.TempDir(), "greeters.db"),
+	}, &conf.Data_Redis{Addr: mr.Addr()})
+	migrateTestData(t, d)
+	repo := NewGreeterRepo(d, testLogger)
+	g := seedGreeters(t, repo, "a")[0]
+
+	err := NewTransaction(d).InTx(ctx, func(txCtx context.Context) error {
+		if _, err := repo.Update(txCtx, &biz.Greeter{ID: g.ID, Hello: "b"}); err != nil {
+			return err
+		}
+		// another request still sees, and caches, the committed greeter
+		found, err := repo.FindByID(ctx, g.ID)
+		if err != nil {
+			return err
+		}
+		if found.Hello != "a" {
+			t.Errorf("FindByID() outside the transaction Hello = %q, want %q", found.Hello, "a")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := repo.FindByID(ctx, g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Hello != "b" {
+		t.Errorf("FindByID() after the commit Hello = %q, want %q", found.Hello, "b")
+	}
+}
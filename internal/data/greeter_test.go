@@ -0,0 +1,194 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+)
+
+// seedGreeters saves a greeter for every hello, in order, and returns them.
+func seedGreeters(t *testing.T, repo biz.GreeterRepo, hellos ...string) []*biz.Greeter {
+	t.Helper()
+	gs := make([]*biz.Greeter, 0, len(hellos))
+	for _, hello := range hellos {
+		g, err := repo.Save(context.Background(), &biz.Greeter{Hello: hello})
+		if err != nil {
+			t.Fatalf("Save(%q) error = %v", hello, err)
+		}
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// ids returns the IDs of gs.
+func ids(gs []*biz.Greeter) []int64 {
+	res := make([]int64, 0, len(gs))
+	for _, g := range gs {
+		res = append(res, g.ID)
+	}
+	return res
+}
+
+func TestGreeterRepoSave(t *testing.T) {
+	repo := NewGreeterRepo(newTestData(t, nil), testLogger)
+
+	tests := []struct {
+		name  string
+		hello string
+	}{
+		{"ascii", "world"},
+		{"unicode", "世界"},
+		{"empty", ""},
+	}
+	var lastID int64
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := repo.Save(context.Background(), &biz.Greeter{Hello: tt.hello})
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if g.ID <= lastID {
+				t.Errorf("Save() ID = %d, want greater than %d", g.ID, lastID)
+			}
+			lastID = g.ID
+			if g.Hello != tt.hello {
+				t.Errorf("Save() Hello = %q, want %q", g.Hello, tt.hello)
+			}
+			if g.CreatedAt.IsZero() || g.UpdatedAt.IsZero() {
+				t.Errorf("Save() timestamps = %v, %v, want them set", g.CreatedAt, g.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestGreeterRepoUpdate(t *testing.T) {
+	repo := NewGreeterRepo(newTestData(t, nil), testLogger)
+	saved := seedGreeters(t, repo, "world")[0]
+
+	tests := []struct {
+		name    string
+		id      int64
+		hello   string
+		wantErr error
+	}{
+		{"existing", saved.ID, "kratos", nil},
+		{"same hello", saved.ID, "kratos", nil},
+		{"not found", saved.ID + 100, "kratos", biz.ErrUserNotFound},
+		{"zero id", 0, "kratos", biz.ErrUserNotFound},
+		{"negative id", -1, "kratos", biz.ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := repo.Update(context.Background(), &biz.Greeter{ID: tt.id, Hello: tt.hello})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if g.ID != tt.id || g.Hello != tt.hello {
+				t.Errorf("Update() = %d %q, want %d %q", g.ID, g.Hello, tt.id, tt.hello)
+			}
+			if !g.CreatedAt.Equal(saved.CreatedAt) {
+				t.Errorf("Update() CreatedAt = %v, want %v", g.CreatedAt, saved.CreatedAt)
+			}
+			if g.UpdatedAt.Before(saved.UpdatedAt) {
+				t.Errorf("Update() UpdatedAt = %v, want after %v", g.UpdatedAt, saved.UpdatedAt)
+			}
+
+			found, err := repo.FindByID(context.Background(), tt.id)
+			if err != nil {
+				t.Fatalf("FindByID() error = %v", err)
+			}
+			if found.Hello != tt.hello {
+				t.Errorf("FindByID() Hello = %q after Update, want %q", found.Hello, tt.hello)
+			}
+		})
+	}
+}
+
+func TestGreeterRepoFindByID(t *testing.T) {
+	repo := NewGreeterRepo(newTestData(t, nil), testLogger)
+	saved := seedGreeters(t, repo, "world", "kratos")
+
+	tests := []struct {
+		name      string
+		id        int64
+		wantHello string
+		wantErr   error
+	}{
+		{"first", saved[0].ID, "world", nil},
+		{"second", saved[1].ID, "kratos", nil},
+		{"not found", saved[1].ID + 1, "", biz.ErrUserNotFound},
+		{"zero", 0, "", biz.ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := repo.FindByID(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindByID() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if g.ID != tt.id || g.Hello != tt.wantHello {
+				t.Errorf("FindByID() = %d %q, want %d %q", g.ID, g.Hello, tt.id, tt.wantHello)
+			}
+		})
+	}
+}
+
+func TestGreeterRepoListByHello(t *testing.T) {
+	repo := NewGreeterRepo(newTestData(t, nil), testLogger)
+	saved := seedGreeters(t, repo, "world", "kratos", "world")
+
+	tests := []struct {
+		name    string
+		hello   string
+		wantIDs []int64
+	}{
+		{"several", "world", []int64{saved[0].ID, saved[2].ID}},
+		{"one", "kratos", []int64{saved[1].ID}},
+		{"none", "gorm", []int64{}},
+		{"case sensitive", "World", []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, err := repo.ListByHello(context.Background(), tt.hello)
+			if err != nil {
+				t.Fatalf("ListByHello() error = %v", err)
+			}
+			if got := ids(gs); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("ListByHello() IDs = %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestGreeterRepoListAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		hellos []string
+	}{
+		{"empty", nil},
+		{"one", []string{"world"}},
+		{"several", []string{"world", "kratos", "world"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewGreeterRepo(newTestData(t, nil), testLogger)
+			saved := seedGreeters(t, repo, tt.hellos...)
+
+			gs, err := repo.ListAll(context.Background())
+			if err != nil {
+				t.Fatalf("ListAll() error = %v", err)
+			}
+			if got, want := ids(gs), ids(saved); !slices.Equal(got, want) {
+				t.Errorf("ListAll() IDs = %v, want %v", got, want)
+			}
+		})
+	}
+}
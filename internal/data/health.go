@@ -0,0 +1,26 @@
+package data
+
+import (
+	"context"
+
+	"github.com/adam-xu-mantle/go-template/internal/health"
+)
+
+// NewHealthRegistry creates the health registry with the checks of the data layer:
+// the primary database and, when configured, Redis.
+func NewHealthRegistry(d *Data) *health.Registry {
+	r := health.NewRegistry()
+	r.Register("database", func(ctx context.Context) error {
+		rawdb, err := d.gorm.DB()
+		if err != nil {
+			return err
+		}
+		return rawdb.PingContext(ctx)
+	})
+	if d.redis != nil {
+		r.Register("redis", func(ctx context.Context) error {
+			return d.redis.Ping(ctx).Err()
+		})
+	}
+	return r
+}
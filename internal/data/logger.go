@@ -35,17 +35,17 @@ func (l Logger) LogMode(lvl logger.LogLevel) logger.Interface {
 
 // Info implements the logger.Interface interface
 func (l Logger) Info(ctx context.Context, msg string, data ...interface{}) {
-	l.log.Info(fmt.Sprintf(msg, data...))
+	l.log.WithContext(ctx).Info(fmt.Sprintf(msg, data...))
 }
 
 // Warn implements the logger.Interface interface
 func (l Logger) Warn(ctx context.Context, msg string, data ...interface{}) {
-	l.log.Warn(fmt.Sprintf(msg, data...))
+	l.log.WithContext(ctx).Warn(fmt.Sprintf(msg, data...))
 }
 
 // Error implements the logger.Interface interface
 func (l Logger) Error(ctx context.Context, msg string, data ...interface{}) {
-	l.log.Error(fmt.Sprintf(msg, data...))
+	l.log.WithContext(ctx).Error(fmt.Sprintf(msg, data...))
 }
 
 // Trace implements the logger.Interface interface
@@ -58,9 +58,11 @@ func (l Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql strin
 		sql = fmt.Sprintf("%sVALUES (...)", sql[:i])
 	}
 
+	// the context of the query carries the request ID of the call that runs it
+	helper := l.log.WithContext(ctx)
 	if elapsedMs < 200 {
-		l.log.Debug("database operation", "duration_ms", elapsedMs, "rows_affected", rows, "sql", sql)
+		helper.Debugw("msg", "database operation", "duration_ms", elapsedMs, "rows_affected", rows, "sql", sql)
 	} else {
-		l.log.Warn("database operation", "duration_ms", elapsedMs, "rows_affected", rows, "sql", sql)
+		helper.Warnw("msg", "database operation", "duration_ms", elapsedMs, "rows_affected", rows, "sql", sql)
 	}
 }
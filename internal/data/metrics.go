@@ -0,0 +1,68 @@
+package data
+
+import (
+	"errors"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
+
+	"gorm.io/gorm"
+)
+
+// startKey is the key of the start time of a statement in its gorm instance.
+const startKey = "metrics:start"
+
+// metricsPlugin records the duration and the errors of every statement run by GORM.
+// Like tracingPlugin, it is registered in the gorm.Config of the primary and the read replicas.
+type metricsPlugin struct {
+	metricer metrics.Metricer
+}
+
+var _ gorm.Plugin = metricsPlugin{}
+
+// Name implements gorm.Plugin.
+func (metricsPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize implements gorm.Plugin, registering the callbacks around every kind of statement.
+func (p metricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("metrics:before_create", p.before),
+		cb.Create().After("*").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("*").Register("metrics:before_query", p.before),
+		cb.Query().After("*").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("*").Register("metrics:before_update", p.before),
+		cb.Update().After("*").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("*").Register("metrics:before_delete", p.before),
+		cb.Delete().After("*").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("*").Register("metrics:before_row", p.before),
+		cb.Row().After("*").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("*").Register("metrics:before_raw", p.before),
+		cb.Raw().After("*").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (metricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func (p metricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		p.metricer.RecordDBQuery(db.Statement.Context, operation, db.Statement.Table, err, time.Since(start))
+	}
+}
@@ -0,0 +1,464 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// migrationFilePattern matches migration files named NNNN_name[.dialect].up.sql or NNNN_name[.dialect].down.sql.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)(?:\.(postgres|mysql|sqlite|sqlserver))?\.(up|down)\.sql$`)
+
+// SchemaMigration is a migration recorded in the schema_migrations table.
+type SchemaMigration struct {
+	Version  int64  `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name     string `gorm:"column:name;size:255;not null"`
+	Checksum string `gorm:"column:checksum;size:64;not null"`
+	// DownChecksum is empty for the migrations applied before the down scripts were checksummed,
+	// whose down scripts are not verified.
+	DownChecksum string    `gorm:"column:down_checksum;size:64;not null;default:''"`
+	AppliedAt    time.Time `gorm:"column:applied_at;not null"`
+}
+
+// TableName returns the table name of the SchemaMigration model.
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migration is a versioned pair of up and down SQL scripts.
+type Migration struct {
+	Version  int64
+	Name     string
+	UpFile   string
+	DownFile string
+	// Checksum is the hex encoded sha256 of the up script.
+	Checksum string
+	// DownChecksum is the hex encoded sha256 of the down script, or of nothing without one.
+	DownChecksum string
+}
+
+// MigrationStatus is a migration together with its state in the database.
+type MigrationStatus struct {
+	*Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies and rolls back versioned SQL migrations from a folder.
+type Migrator struct {
+	db      *gorm.DB
+	dir     string
+	dialect string
+	log     *log.Helper
+}
+
+// NewMigrator creates a Migrator for the migrations in dir,
+// selecting the script variants written for the dialect of data.
+func NewMigrator(data *Data, dir string, logger log.Logger) *Migrator {
+	return &Migrator{
+		db:      data.gorm,
+		dir:     dir,
+		dialect: data.gorm.Dialector.Name(),
+		log:     log.NewHelper(log.With(logger, "module", "migrate")),
+	}
+}
+
+// Dialect returns the dialect the migration scripts are selected for.
+func (m *Migrator) Dialect() string {
+	return m.dialect
+}
+
+// Up applies at most n pending migrations, or all of them when n <= 0.
+// It returns the number of migrations applied.
+func (m *Migrator) Up(ctx context.Context, n int) (int, error) {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range migrations {
+		if n > 0 && count >= n {
+			break
+		}
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if err := m.apply(ctx, mig); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Down rolls back at most n applied migrations, newest first, or all of them when n <= 0.
+// It returns the number of migrations rolled back.
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if n > 0 && count >= n {
+			break
+		}
+		mig := migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if err := m.rollback(ctx, mig); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Goto migrates the database up or down so that version is the latest applied migration.
+// A version of 0 rolls back every migration.
+func (m *Migrator) Goto(ctx context.Context, version int64) error {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version != 0 && !containsVersion(migrations, version) {
+		return fmt.Errorf("migration %d not found in %s", version, m.dir)
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		mig := migrations[i]
+		if _, ok := applied[mig.Version]; !ok || mig.Version <= version {
+			continue
+		}
+		if err := m.rollback(ctx, mig); err != nil {
+			return err
+		}
+	}
+
+	for _, mig := range migrations {
+		if _, ok := applied[mig.Version]; ok || mig.Version > version {
+			continue
+		}
+		if err := m.apply(ctx, mig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status returns every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(migrations))
+	for _, mig := range migrations {
+		s := &MigrationStatus{Migration: mig}
+		if rec, ok := applied[mig.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = rec.AppliedAt
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// prepare loads the migration files and the applied migrations, and verifies they agree.
+func (m *Migrator) prepare(ctx context.Context) ([]*Migration, map[int64]SchemaMigration, error) {
+	migrations, err := LoadMigrations(m.dir, m.dialect)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create schema_migrations table")
+	}
+
+	var records []SchemaMigration
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read schema_migrations")
+	}
+
+	applied := make(map[int64]SchemaMigration, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = rec
+	}
+
+	if err := verifyMigrations(migrations, applied); err != nil {
+		return nil, nil, err
+	}
+	return migrations, applied, nil
+}
+
+// verifyMigrations refuses to run when an applied migration was edited or removed,
+// or when a pending migration would be applied out of order.
+func verifyMigrations(migrations []*Migration, applied map[int64]SchemaMigration) error {
+	var latest int64
+	for v := range applied {
+		if v > latest {
+			latest = v
+		}
+	}
+
+	known := make(map[int64]bool, len(migrations))
+	for _, mig := range migrations {
+		known[mig.Version] = true
+		rec, ok := applied[mig.Version]
+		if !ok {
+			if mig.Version < latest {
+				return fmt.Errorf("migration %d_%s is pending but older than the latest applied version %d", mig.Version, mig.Name, latest)
+			}
+			continue
+		}
+		if rec.Checksum != mig.Checksum {
+			return fmt.Errorf("checksum mismatch for applied migration %d_%s: file was modified after it was applied", mig.Version, mig.Name)
+		}
+		if rec.DownChecksum != "" && rec.DownChecksum != mig.DownChecksum {
+			return fmt.Errorf("checksum mismatch for the down script of applied migration %d_%s: file was modified after it was applied", mig.Version, mig.Name)
+		}
+	}
+
+	for v, rec := range applied {
+		if !known[v] {
+			return fmt.Errorf("applied migration %d_%s is missing from the migrations folder", v, rec.Name)
+		}
+	}
+	return nil
+}
+
+// apply runs the up script of mig and records it, in a single transaction.
+// MySQL commits DDL statements implicitly, so a failed MySQL script keeps the statements before the failure.
+func (m *Migrator) apply(ctx context.Context, mig *Migration) error {
+	script, err := readMigrationFile(mig.UpFile)
+	if err != nil {
+		return err
+	}
+
+	m.log.Infof("applying migration %d_%s", mig.Version, mig.Name)
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if strings.TrimSpace(script) != "" {
+			if err := tx.Exec(script).Error; err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error executing SQL script: %s", mig.UpFile))
+			}
+		}
+		return tx.Create(&SchemaMigration{
+			Version:      mig.Version,
+			Name:         mig.Name,
+			Checksum:     mig.Checksum,
+			DownChecksum: mig.DownChecksum,
+			AppliedAt:    time.Now().UTC(),
+		}).Error
+	})
+	return errors.Wrapf(err, "failed to apply migration %d_%s", mig.Version, mig.Name)
+}
+
+// rollback runs the down script of mig and removes its record, in a single transaction.
+func (m *Migrator) rollback(ctx context.Context, mig *Migration) error {
+	if mig.DownFile == "" {
+		return fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+	}
+	script, err := readMigrationFile(mig.DownFile)
+	if err != nil {
+		return err
+	}
+
+	m.log.Infof("rolling back migration %d_%s", mig.Version, mig.Name)
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if strings.TrimSpace(script) != "" {
+			if err := tx.Exec(script).Error; err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error executing SQL script: %s", mig.DownFile))
+			}
+		}
+		return tx.Delete(&SchemaMigration{Version: mig.Version}).Error
+	})
+	return errors.Wrapf(err, "failed to roll back migration %d_%s", mig.Version, mig.Name)
+}
+
+// migrationScripts holds the generic and dialect specific scripts of one direction of a migration.
+type migrationScripts struct {
+	generic string
+	dialect string
+}
+
+func (s migrationScripts) pick() string {
+	if s.dialect != "" {
+		return s.dialect
+	}
+	return s.generic
+}
+
+// LoadMigrations reads the migrations in dir for the given dialect, ordered by version.
+// A script named NNNN_name.<dialect>.up.sql takes precedence over the generic NNNN_name.up.sql,
+// and scripts written for other dialects are ignored. Files other than *.sql are ignored.
+func LoadMigrations(dir, dialect string) ([]*Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Failed to read migrations folder: %s", dir))
+	}
+
+	byVersion := make(map[int64]*Migration)
+	ups := make(map[int64]*migrationScripts)
+	downs := make(map[int64]*migrationScripts)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q, expected NNNN_name[.dialect].up.sql or NNNN_name[.dialect].down.sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid migration version in %q", entry.Name()))
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+			ups[version] = &migrationScripts{}
+			downs[version] = &migrationScripts{}
+		} else if mig.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, mig.Name, match[2])
+		}
+
+		scripts := ups[version]
+		if match[4] == "down" {
+			scripts = downs[version]
+		}
+		path := filepath.Join(dir, entry.Name())
+		switch match[3] {
+		case "":
+			scripts.generic = path
+		case dialect:
+			scripts.dialect = path
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for version, mig := range byVersion {
+		mig.UpFile = ups[version].pick()
+		mig.DownFile = downs[version].pick()
+		if mig.UpFile == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script for dialect %s", mig.Version, mig.Name, dialect)
+		}
+		if mig.Checksum, err = checksumMigrationFile(mig.UpFile); err != nil {
+			return nil, err
+		}
+		if mig.DownChecksum, err = checksumMigrationFile(mig.DownFile); err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, mig)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// CreateMigration creates empty up and down scripts for the next version in dir.
+// When dialect is set the scripts are only used for that dialect.
+// It returns the paths of the created files.
+func CreateMigration(dir, name, dialect string) (string, string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "_"))
+	if !migrationFilePattern.MatchString("0_" + name + ".up.sql") {
+		return "", "", fmt.Errorf("invalid migration name %q, only letters, digits and underscores are allowed", name)
+	}
+
+	suffix := ""
+	if dialect != "" {
+		d, err := Dialect(dialect)
+		if err != nil {
+			return "", "", err
+		}
+		suffix = "." + d
+	}
+
+	next, err := nextMigrationVersion(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s%s", next, name, suffix))
+	up, down := base+".up.sql", base+".down.sql"
+	for _, path := range []string{up, down} {
+		// #nosec G306 - migration scripts are shared with the team and deployment tooling
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("-- %s\n", filepath.Base(path))), 0o644); err != nil {
+			return "", "", errors.Wrap(err, fmt.Sprintf("Error writing migration file: %s", path))
+		}
+	}
+	return up, down, nil
+}
+
+// nextMigrationVersion returns the version following the highest one in dir, across all dialects.
+func nextMigrationVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("Failed to read migrations folder: %s", dir))
+	}
+
+	var latest int64
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err == nil && version > latest {
+			latest = version
+		}
+	}
+	return latest + 1, nil
+}
+
+func readMigrationFile(path string) (string, error) {
+	// #nosec G304 - path comes from the migrations folder listing
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("Error reading SQL file: %s", path))
+	}
+	return string(content), nil
+}
+
+// checksumMigrationFile returns the hex encoded sha256 of the script at path, or of nothing when path is empty.
+func checksumMigrationFile(path string) (string, error) {
+	var script string
+	if path != "" {
+		var err error
+		if script, err = readMigrationFile(path); err != nil {
+			return "", err
+		}
+	}
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func containsVersion(migrations []*Migration, version int64) bool {
+	for _, mig := range migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,409 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeMigrations writes the scripts of files, by file name, into a new folder and returns it.
+func writeMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeMigrationFiles(t, dir, files)
+	return dir
+}
+
+// writeMigrationFiles writes the scripts of files, by file name, into dir.
+func writeMigrationFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, script := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// tableMigrations returns the scripts of migrations creating the tables t1 to tn.
+func tableMigrations(n int) map[string]string {
+	files := make(map[string]string, 2*n)
+	for i := 1; i <= n; i++ {
+		table := fmt.Sprintf("t%d", i)
+		prefix := fmt.Sprintf("%04d_create_%s", i, table)
+		files[prefix+".up.sql"] = "CREATE TABLE " + table + " (id INTEGER PRIMARY KEY);"
+		files[prefix+".down.sql"] = "DROP TABLE " + table + ";"
+	}
+	return files
+}
+
+// assertTables fails the test unless exactly the tables t1 to tn of tableMigrations(max) exist.
+func assertTables(t *testing.T, d *Data, n, max int) {
+	t.Helper()
+	for i := 1; i <= max; i++ {
+		table := fmt.Sprintf("t%d", i)
+		if got, want := d.gorm.Migrator().HasTable(table), i <= n; got != want {
+			t.Errorf("HasTable(%s) = %v, want %v", table, got, want)
+		}
+	}
+}
+
+func TestMigratorUpDownGoto(t *testing.T) {
+	d := openTestData(t, nil)
+	m := NewMigrator(d, writeMigrations(t, tableMigrations(3)), testLogger)
+	ctx := context.Background()
+
+	steps := []struct {
+		name      string
+		run       func() (int, error)
+		wantCount int
+		wantErr   string
+		// wantTables is the number of migrations applied after the step
+		wantTables int
+	}{
+		{"up one", func() (int, error) { return m.Up(ctx, 1) }, 1, "", 1},
+		{"up all", func() (int, error) { return m.Up(ctx, 0) }, 2, "", 3},
+		{"up nothing pending", func() (int, error) { return m.Up(ctx, 0) }, 0, "", 3},
+		{"down one", func() (int, error) { return m.Down(ctx, 1) }, 1, "", 2},
+		{"goto older", func() (int, error) { return 0, m.Goto(ctx, 1) }, 0, "", 1},
+		{"goto newer", func() (int, error) { return 0, m.Goto(ctx, 3) }, 0, "", 3},
+		{"goto unknown", func() (int, error) { return 0, m.Goto(ctx, 9) }, 0, "migration 9 not found", 3},
+		{"goto zero", func() (int, error) { return 0, m.Goto(ctx, 0) }, 0, "", 0},
+		{"down nothing applied", func() (int, error) { return m.Down(ctx, 0) }, 0, "", 0},
+		{"up again", func() (int, error) { return m.Up(ctx, 0) }, 3, "", 3},
+		{"down all", func() (int, error) { return m.Down(ctx, 0) }, 3, "", 0},
+	}
+	for _, s := range steps {
+		count, err := s.run()
+		if s.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), s.wantErr) {
+				t.Fatalf("%s: error = %v, want %q", s.name, err, s.wantErr)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: error = %v", s.name, err)
+		}
+		if count != s.wantCount {
+			t.Errorf("%s: count = %d, want %d", s.name, count, s.wantCount)
+		}
+		assertTables(t, d, s.wantTables, 3)
+
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			t.Fatalf("%s: Status() error = %v", s.name, err)
+		}
+		for _, st := range statuses {
+			if want := st.Version <= int64(s.wantTables); st.Applied != want {
+				t.Errorf("%s: migration %d applied = %v, want %v", s.name, st.Version, st.Applied, want)
+			}
+		}
+	}
+}
+
+func TestMigratorUpRollsBackFailedMigration(t *testing.T) {
+	d := openTestData(t, nil)
+	files := tableMigrations(1)
+	files["0002_broken.up.sql"] = "CREATE TABLE t2 (id INTEGER PRIMARY KEY); NOT SQL;"
+	files["0002_broken.down.sql"] = "DROP TABLE t2;"
+	m := NewMigrator(d, writeMigrations(t, files), testLogger)
+
+	count, err := m.Up(context.Background(), 0)
+	if err == nil {
+		t.Fatal("Up() with a broken script succeeded")
+	}
+	if count != 1 {
+		t.Errorf("Up() count = %d, want 1", count)
+	}
+	assertTables(t, d, 1, 2)
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[1].Applied {
+		t.Error("the broken migration is recorded as applied")
+	}
+}
+
+func TestMigratorRefusesChangedMigrations(t *testing.T) {
+	tests := []struct {
+		name string
+		// change edits the migrations folder once the migrations are applied
+		change  func(t *testing.T, dir string)
+		wantErr string
+	}{
+		{
+			name: "edited",
+			change: func(t *testing.T, dir string) {
+				writeMigrationFiles(t, dir, map[string]string{
+					"0001_create_t1.up.sql": "CREATE TABLE t1 (id INTEGER PRIMARY KEY, name TEXT);",
+				})
+			},
+			wantErr: "checksum mismatch for applied migration 1_create_t1",
+		},
+		{
+			name: "down script edited",
+			change: func(t *testing.T, dir string) {
+				writeMigrationFiles(t, dir, map[string]string{
+					"0003_create_t3.down.sql": "DROP TABLE t1;",
+				})
+			},
+			wantErr: "checksum mismatch for the down script of applied migration 3_create_t3",
+		},
+		{
+			name: "removed",
+			change: func(t *testing.T, dir string) {
+				for _, name := range []string{"0003_create_t3.up.sql", "0003_create_t3.down.sql"} {
+					if err := os.Remove(filepath.Join(dir, name)); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			wantErr: "applied migration 3_create_t3 is missing",
+		},
+		{
+			name: "out of order",
+			change: func(t *testing.T, dir string) {
+				writeMigrationFiles(t, dir, map[string]string{
+					"0002_create_t2.up.sql":   "CREATE TABLE t2 (id INTEGER PRIMARY KEY);",
+					"0002_create_t2.down.sql": "DROP TABLE t2;",
+				})
+			},
+			wantErr: "migration 2_create_t2 is pending but older than the latest applied version 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := openTestData(t, nil)
+			// the migrations 1 and 3, so that a migration 2 added later is out of order
+			files := tableMigrations(3)
+			delete(files, "0002_create_t2.up.sql")
+			delete(files, "0002_create_t2.down.sql")
+			dir := writeMigrations(t, files)
+			m := NewMigrator(d, dir, testLogger)
+			if _, err := m.Up(context.Background(), 0); err != nil {
+				t.Fatal(err)
+			}
+
+			tt.change(t, dir)
+			if _, err := m.Up(context.Background(), 0); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Up() error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := m.Down(context.Background(), 0); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Down() error = %v, want %q", err, tt.wantErr)
+			}
+			for table, want := range map[string]bool{"t1": true, "t2": false, "t3": true} {
+				if got := d.gorm.Migrator().HasTable(table); got != want {
+					t.Errorf("HasTable(%s) = %v, want %v", table, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyMigrations(t *testing.T) {
+	migrations := []*Migration{
+		{Version: 1, Name: "one", Checksum: "c1", DownChecksum: "d1"},
+		{Version: 2, Name: "two", Checksum: "c2"},
+		{Version: 3, Name: "three", Checksum: "c3"},
+	}
+
+	tests := []struct {
+		name    string
+		applied map[int64]SchemaMigration
+		wantErr string
+	}{
+		{"none applied", map[int64]SchemaMigration{}, ""},
+		{"prefix applied", map[int64]SchemaMigration{1: {Checksum: "c1"}, 2: {Checksum: "c2"}}, ""},
+		{"all applied", map[int64]SchemaMigration{1: {Checksum: "c1"}, 2: {Checksum: "c2"}, 3: {Checksum: "c3"}}, ""},
+		{"checksum mismatch", map[int64]SchemaMigration{1: {Checksum: "c1"}, 2: {Checksum: "edited"}}, "checksum mismatch for applied migration 2_two"},
+		{"down checksum mismatch", map[int64]SchemaMigration{1: {Checksum: "c1", DownChecksum: "edited"}}, "checksum mismatch for the down script of applied migration 1_one"},
+		{"down checksum matches", map[int64]SchemaMigration{1: {Checksum: "c1", DownChecksum: "d1"}}, ""},
+		{"down checksum not recorded", map[int64]SchemaMigration{1: {Checksum: "c1"}}, ""},
+		{"gap", map[int64]SchemaMigration{1: {Checksum: "c1"}, 3: {Checksum: "c3"}}, "migration 2_two is pending but older than the latest applied version 3"},
+		{"missing file", map[int64]SchemaMigration{1: {Checksum: "c1"}, 2: {Checksum: "c2"}, 3: {Checksum: "c3"}, 4: {Name: "four", Checksum: "c4"}},
+			"applied migration 4_four is missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyMigrations(migrations, tt.applied)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("verifyMigrations() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifyMigrations() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMigrationsDialect(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"0001_generic.up.sql":            "CREATE TABLE generic (id INTEGER);",
+		"0001_generic.down.sql":          "DROP TABLE generic;",
+		"0002_specific.up.sql":           "CREATE TABLE specific (id INTEGER);",
+		"0002_specific.postgres.up.sql":  "CREATE TABLE specific (id BIGSERIAL);",
+		"0002_specific.sqlite.up.sql":    "CREATE TABLE specific (id INTEGER PRIMARY KEY AUTOINCREMENT);",
+		"0002_specific.down.sql":         "DROP TABLE specific;",
+		"0002_specific.mysql.down.sql":   "DROP TABLE IF EXISTS specific;",
+		"0003_generic_up.up.sql":         "CREATE INDEX idx ON specific (id);",
+		"0003_generic_up.mysql.down.sql": "DROP INDEX idx ON specific;",
+		"README.md":                      "not a migration",
+	})
+
+	tests := []struct {
+		dialect string
+		// wantUp and wantDown are the file names of the scripts of each version, "" for none
+		wantUp   []string
+		wantDown []string
+	}{
+		{
+			dialect:  "postgres",
+			wantUp:   []string{"0001_generic.up.sql", "0002_specific.postgres.up.sql", "0003_generic_up.up.sql"},
+			wantDown: []string{"0001_generic.down.sql", "0002_specific.down.sql", ""},
+		},
+		{
+			dialect:  "sqlite",
+			wantUp:   []string{"0001_generic.up.sql", "0002_specific.sqlite.up.sql", "0003_generic_up.up.sql"},
+			wantDown: []string{"0001_generic.down.sql", "0002_specific.down.sql", ""},
+		},
+		{
+			dialect:  "mysql",
+			wantUp:   []string{"0001_generic.up.sql", "0002_specific.up.sql", "0003_generic_up.up.sql"},
+			wantDown: []string{"0001_generic.down.sql", "0002_specific.mysql.down.sql", "0003_generic_up.mysql.down.sql"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			migrations, err := LoadMigrations(dir, tt.dialect)
+			if err != nil {
+				t.Fatalf("LoadMigrations() error = %v", err)
+			}
+			if len(migrations) != len(tt.wantUp) {
+				t.Fatalf("LoadMigrations() returned %d migrations, want %d", len(migrations), len(tt.wantUp))
+			}
+			for i, mig := range migrations {
+				if mig.Version != int64(i+1) {
+					t.Errorf("migration %d has version %d", i, mig.Version)
+				}
+				if got := filepath.Base(mig.UpFile); got != tt.wantUp[i] {
+					t.Errorf("migration %d up script = %s, want %s", mig.Version, got, tt.wantUp[i])
+				}
+				got := ""
+				if mig.DownFile != "" {
+					got = filepath.Base(mig.DownFile)
+				}
+				if got != tt.wantDown[i] {
+					t.Errorf("migration %d down script = %q, want %q", mig.Version, got, tt.wantDown[i])
+				}
+			}
+		})
+	}
+
+	// the checksum is the one of the script of the dialect, so that each database verifies its own
+	postgres, _ := LoadMigrations(dir, "postgres")
+	sqlite, _ := LoadMigrations(dir, "sqlite")
+	if postgres[0].Checksum != sqlite[0].Checksum {
+		t.Error("the checksums of a generic script differ between dialects")
+	}
+	if postgres[1].Checksum == sqlite[1].Checksum {
+		t.Error("the checksums of dialect scripts are equal")
+	}
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "no up script for the dialect",
+			files:   map[string]string{"0001_only_mysql.mysql.up.sql": "SELECT 1;"},
+			wantErr: "migration 1_only_mysql has no up script for dialect sqlite",
+		},
+		{
+			name:    "invalid name",
+			files:   map[string]string{"create_greeters.sql": "SELECT 1;"},
+			wantErr: `invalid migration file name "create_greeters.sql"`,
+		},
+		{
+			name:    "unknown dialect",
+			files:   map[string]string{"0001_a.oracle.up.sql": "SELECT 1;"},
+			wantErr: `invalid migration file name "0001_a.oracle.up.sql"`,
+		},
+		{
+			name:    "duplicate version",
+			files:   map[string]string{"0001_a.up.sql": "SELECT 1;", "0001_b.up.sql": "SELECT 2;"},
+			wantErr: "duplicate migration version 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(writeMigrations(t, tt.files), "sqlite")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadMigrations() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMigratorDialect(t *testing.T) {
+	d := openTestData(t, nil)
+	dir := writeMigrations(t, map[string]string{
+		"0001_create_t1.up.sql":        "CREATE TABLE wrong (id INTEGER);",
+		"0001_create_t1.sqlite.up.sql": "CREATE TABLE t1 (id INTEGER PRIMARY KEY);",
+		"0001_create_t1.down.sql":      "DROP TABLE t1;",
+	})
+	m := NewMigrator(d, dir, testLogger)
+	if got := m.Dialect(); got != "sqlite" {
+		t.Fatalf("Dialect() = %q, want sqlite", got)
+	}
+	if _, err := m.Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	if !d.gorm.Migrator().HasTable("t1") || d.gorm.Migrator().HasTable("wrong") {
+		t.Error("the generic script ran instead of the sqlite one")
+	}
+}
+
+func TestMigratorVerifiesDownScriptsOnceRecorded(t *testing.T) {
+	ctx := context.Background()
+	d := openTestData(t, nil)
+	dir := writeMigrations(t, tableMigrations(1))
+	m := NewMigrator(d, dir, testLogger)
+	migrations, err := LoadMigrations(dir, m.Dialect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// migration 1 applied when schema_migrations had no down_checksum column
+	if err := d.gorm.Exec("CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL, checksum TEXT NOT NULL, applied_at DATETIME NOT NULL)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := d.gorm.Exec("INSERT INTO schema_migrations VALUES (1, 'create_t1', ?, CURRENT_TIMESTAMP)", migrations[0].Checksum).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := d.gorm.Exec("CREATE TABLE t1 (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// its down script is not verified, the next migrations are recorded with theirs
+	writeMigrationFiles(t, dir, map[string]string{
+		"0001_create_t1.down.sql": "DROP TABLE IF EXISTS t1;",
+		"0002_create_t2.up.sql":   "CREATE TABLE t2 (id INTEGER PRIMARY KEY);",
+		"0002_create_t2.down.sql": "DROP TABLE t2;",
+	})
+	if _, err := m.Up(ctx, 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	var rec SchemaMigration
+	if err := d.gorm.First(&rec, 2).Error; err != nil {
+		t.Fatal(err)
+	}
+	if rec.DownChecksum == "" {
+		t.Error("DownChecksum of a migration applied now is empty")
+	}
+}
@@ -0,0 +1,46 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultRedisDialTimeout is used when conf.Data.Redis leaves dial_timeout unset.
+const defaultRedisDialTimeout = 5 * time.Second
+
+// newRedis connects to the Redis configured in c and pings it.
+// It returns nil when no Redis address is configured.
+func newRedis(c *conf.Data_Redis) (*redis.Client, error) {
+	if c == nil || c.Addr == "" {
+		return nil, nil
+	}
+
+	dialTimeout := durationOrDefault(c.DialTimeout, defaultRedisDialTimeout)
+	rdb := redis.NewClient(&redis.Options{
+		Network:      c.Network,
+		Addr:         c.Addr,
+		Password:     c.Password,
+		DB:           int(c.Db),
+		DialTimeout:  dialTimeout,
+		ReadTimeout:  c.ReadTimeout.AsDuration(),
+		WriteTimeout: c.WriteTimeout.AsDuration(),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		_ = rdb.Close()
+		return nil, fmt.Errorf("failed to connect to redis (%s): %w", c.Addr, err)
+	}
+	return rdb, nil
+}
+
+// Redis returns the Redis client, or nil when Redis is not configured.
+func (d *Data) Redis() *redis.Client {
+	return d.redis
+}
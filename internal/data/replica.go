@@ -0,0 +1,170 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// defaultReplicaHealthCheckInterval is used when conf.Data.Database leaves replica_health_check_interval unset.
+const defaultReplicaHealthCheckInterval = 5 * time.Second
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose reads are served by the primary instead of a read replica,
+// e.g. to read back a row right after writing it.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// isPrimaryForced reports whether reads in ctx must be served by the primary.
+func isPrimaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// replica is a read replica and its last known health.
+// It is opened lazily so that a replica that is down on startup joins once it comes up.
+type replica struct {
+	name    string
+	open    func() (*gorm.DB, error)
+	db      atomic.Pointer[gorm.DB]
+	healthy atomic.Bool
+}
+
+// ping opens the replica if needed and checks it is reachable within timeout.
+func (r *replica) ping(timeout time.Duration) error {
+	db := r.db.Load()
+	if db == nil {
+		var err error
+		if db, err = r.open(); err != nil {
+			return err
+		}
+		r.db.Store(db)
+	}
+	return ping(db, timeout)
+}
+
+// replicaSet spreads reads over its healthy replicas round-robin,
+// ejecting replicas that fail their health check until they pass again.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	timeout  time.Duration
+	log      *log.Helper
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newReplicaSet creates a replicaSet from the replica openers and checks the health of every replica once.
+func newReplicaSet(openers []func() (*gorm.DB, error), timeout time.Duration, logger *log.Helper) *replicaSet {
+	s := &replicaSet{
+		replicas: make([]*replica, 0, len(openers)),
+		timeout:  timeout,
+		log:      logger,
+		stop:     make(chan struct{}),
+	}
+	for i, open := range openers {
+		r := &replica{name: fmt.Sprintf("replica-%d", i), open: open}
+		// replicas start as healthy so that the first check logs the ones that are not
+		r.healthy.Store(true)
+		s.replicas = append(s.replicas, r)
+	}
+	s.check()
+	return s
+}
+
+// pick returns the next healthy replica, or nil when none is healthy.
+func (s *replicaSet) pick() *gorm.DB {
+	n := uint64(len(s.replicas))
+	for i := uint64(0); i < n; i++ {
+		r := s.replicas[(s.next.Add(1)-1)%n]
+		if r.healthy.Load() {
+			return r.db.Load()
+		}
+	}
+	return nil
+}
+
+// watch checks the health of every replica each interval until close is called.
+func (s *replicaSet) watch(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.check()
+			}
+		}
+	}()
+}
+
+// check pings every replica and updates its health.
+func (s *replicaSet) check() {
+	for _, r := range s.replicas {
+		err := r.ping(s.timeout)
+		healthy := err == nil
+		if r.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			s.log.Infof("read replica %s is healthy, routing reads to it", r.name)
+		} else {
+			s.log.Warnf("read replica %s is unhealthy, ejecting it: %v", r.name, err)
+		}
+	}
+}
+
+// configure calls fn with the connection pool of every replica that is open.
+func (s *replicaSet) configure(fn func(*sql.DB)) {
+	for _, r := range s.replicas {
+		db := r.db.Load()
+		if db == nil {
+			continue
+		}
+		if rawdb, err := db.DB(); err == nil {
+			fn(rawdb)
+		}
+	}
+}
+
+// close stops the health checks and closes every replica.
+func (s *replicaSet) close() {
+	close(s.stop)
+	s.wg.Wait()
+	for _, r := range s.replicas {
+		db := r.db.Load()
+		if db == nil {
+			continue
+		}
+		rawdb, err := db.DB()
+		if err != nil {
+			continue
+		}
+		if err := rawdb.Close(); err != nil {
+			s.log.Errorf("failed to close read replica %s: %v", r.name, err)
+		}
+	}
+}
+
+func ping(db *gorm.DB, timeout time.Duration) error {
+	rawdb, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return rawdb.PingContext(ctx)
+}
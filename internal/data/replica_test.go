@@ -0,0 +1,160 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"gorm.io/gorm"
+)
+
+// openTestReplicas returns a Data on a sqlite primary and n sqlite read replicas, whose whoami
+// table holds the name of the database. The health of the replicas is only checked on startup.
+func openTestReplicas(t *testing.T, n int) *Data {
+	t.Helper()
+	dir := t.TempDir()
+	c := &conf.Data_Database{
+		Driver:                     "sqlite",
+		Source:                     filepath.Join(dir, "primary.db"),
+		ReplicaHealthCheckInterval: durationpb.New(time.Hour),
+	}
+	for i := range n {
+		c.Replicas = append(c.Replicas, filepath.Join(dir, fmt.Sprintf("replica-%d.db", i)))
+	}
+	d := openTestDatabase(t, c, nil)
+
+	dbs := map[string]*gorm.DB{"primary": d.gorm}
+	for i, r := range d.replicas.replicas {
+		dbs[fmt.Sprintf("replica-%d", i)] = r.db.Load()
+	}
+	for name, db := range dbs {
+		if err := db.Exec("CREATE TABLE whoami (name TEXT)").Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Exec("INSERT INTO whoami VALUES (?)", name).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d
+}
+
+// whoami returns the name of the database db reads from.
+func whoami(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+	var name string
+	if err := db.Raw("SELECT name FROM whoami").Scan(&name).Error; err != nil {
+		t.Fatalf("whoami error = %v", err)
+	}
+	return name
+}
+
+// reads returns the names of the databases n reads in ctx are served by.
+func reads(t *testing.T, d *Data, ctx context.Context, n int) []string {
+	t.Helper()
+	names := make([]string, 0, n)
+	for range n {
+		names = append(names, whoami(t, d.ReadDB(ctx)))
+	}
+	return names
+}
+
+// eject closes the replica i of d and runs the health checks, as if it went down.
+func eject(t *testing.T, d *Data, i int) {
+	t.Helper()
+	rawdb, err := d.replicas.replicas[i].db.Load().DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = rawdb.Close()
+	d.replicas.check()
+}
+
+func TestDataReadDB(t *testing.T) {
+	ctx := context.Background()
+	d := openTestReplicas(t, 2)
+
+	if got, want := reads(t, d, ctx, 4), []string{"replica-0", "replica-1", "replica-0", "replica-1"}; !slices.Equal(got, want) {
+		t.Errorf("reads = %v, want round-robin %v", got, want)
+	}
+	if got, want := reads(t, d, WithPrimary(ctx), 2), []string{"primary", "primary"}; !slices.Equal(got, want) {
+		t.Errorf("reads WithPrimary = %v, want %v", got, want)
+	}
+	err := NewTransaction(d).InTx(ctx, func(ctx context.Context) error {
+		// a read sees the writes of the transaction
+		if err := d.DB(ctx).Exec("UPDATE whoami SET name = ?", "primary in tx").Error; err != nil {
+			return err
+		}
+		if got, want := reads(t, d, ctx, 2), []string{"primary in tx", "primary in tx"}; !slices.Equal(got, want) {
+			t.Errorf("reads in a transaction = %v, want %v", got, want)
+		}
+		return errTestRollback
+	})
+	if !errors.Is(err, errTestRollback) {
+		t.Fatalf("InTx() error = %v, want %v", err, errTestRollback)
+	}
+
+	eject(t, d, 0)
+	if got, want := reads(t, d, ctx, 3), []string{"replica-1", "replica-1", "replica-1"}; !slices.Equal(got, want) {
+		t.Errorf("reads with replica-0 ejected = %v, want %v", got, want)
+	}
+	eject(t, d, 1)
+	if got, want := reads(t, d, ctx, 2), []string{"primary", "primary"}; !slices.Equal(got, want) {
+		t.Errorf("reads without a healthy replica = %v, want the primary %v", got, want)
+	}
+}
+
+func TestReplicaSetCheck(t *testing.T) {
+	// the replica has a single connection, see openTestData
+	replica := openTestData(t, nil)
+	var down bool
+	opened := 0
+	open := func() (*gorm.DB, error) {
+		if down {
+			return nil, errors.New("connection refused")
+		}
+		opened++
+		return replica.gorm, nil
+	}
+
+	// the replica is down on startup, it joins once it comes up
+	down = true
+	s := newReplicaSet([]func() (*gorm.DB, error){open}, 10*time.Millisecond, log.NewHelper(testLogger))
+	if db := s.pick(); db != nil {
+		t.Fatal("pick() returned a replica that is down")
+	}
+	down = false
+	s.check()
+	if db := s.pick(); db != replica.gorm {
+		t.Fatalf("pick() = %v, want the replica once it is up", db)
+	}
+
+	// the replica does not answer the health check in time, it is ejected until it does again
+	rawdb, err := replica.gorm.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := rawdb.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.check()
+	if db := s.pick(); db != nil {
+		t.Fatal("pick() returned a replica that failed its health check")
+	}
+	_ = conn.Close()
+	s.check()
+	if db := s.pick(); db != replica.gorm {
+		t.Fatalf("pick() = %v, want the replica once it passes its health check", db)
+	}
+	if opened != 1 {
+		t.Errorf("the replica was opened %d times, want once", opened)
+	}
+}
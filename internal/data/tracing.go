@@ -0,0 +1,96 @@
+package data
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// tracerName is the name of the tracer of the database spans.
+const tracerName = "github.com/adam-xu-mantle/go-template/internal/data"
+
+// spanKey is the key of the span of a statement in its gorm instance.
+const spanKey = "otel:span"
+
+// tracingPlugin creates a span for every statement run by GORM, as a child of the span of
+// the context of the statement. It is a gorm.Plugin, so that the primary and the read
+// replicas opened with the same gorm.Config are all traced.
+type tracingPlugin struct{}
+
+var _ gorm.Plugin = tracingPlugin{}
+
+// Name implements gorm.Plugin.
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize implements gorm.Plugin, registering the callbacks around every kind of statement.
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("tracing:before_create", p.before("create")),
+		cb.Create().After("*").Register("tracing:after_create", p.after("create")),
+		cb.Query().Before("*").Register("tracing:before_query", p.before("query")),
+		cb.Query().After("*").Register("tracing:after_query", p.after("query")),
+		cb.Update().Before("*").Register("tracing:before_update", p.before("update")),
+		cb.Update().After("*").Register("tracing:after_update", p.after("update")),
+		cb.Delete().Before("*").Register("tracing:before_delete", p.before("delete")),
+		cb.Delete().After("*").Register("tracing:after_delete", p.after("delete")),
+		cb.Row().Before("*").Register("tracing:before_row", p.before("row")),
+		cb.Row().After("*").Register("tracing:after_row", p.after("row")),
+		cb.Raw().Before("*").Register("tracing:before_raw", p.before("raw")),
+		cb.Raw().After("*").Register("tracing:after_raw", p.after("raw")),
+	)
+}
+
+func (tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+		ctx, span := otel.Tracer(tracerName).Start(db.Statement.Context, operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemKey.String(db.Dialector.Name()),
+				semconv.DBOperation(strings.ToUpper(operation)),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func (tracingPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span, ok := v.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		// the table is known once the statement is built
+		if db.Statement.Table != "" {
+			span.SetName(operation + " " + db.Statement.Table)
+			span.SetAttributes(semconv.DBSQLTable(db.Statement.Table))
+		}
+		// the statement has placeholders rather than its values, which may be secrets
+		span.SetAttributes(
+			semconv.DBStatement(db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+		)
+		if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
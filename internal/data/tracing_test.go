@@ -0,0 +1,118 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordSpans installs a global tracer provider recording the spans in the returned exporter
+// until the test ends.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return exp
+}
+
+func TestTracingPlugin(t *testing.T) {
+	d := newTestData(t, nil)
+	repo := NewGreeterRepo(d, testLogger)
+	exp := recordSpans(t)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	g, err := repo.Save(ctx, &biz.Greeter{Hello: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.FindByID(ctx, g.ID+1); err == nil {
+		t.Fatal("FindByID() error = nil, want not found")
+	}
+	if err := d.gorm.WithContext(ctx).Exec("SELECT * FROM missing").Error; err == nil {
+		t.Fatal("Exec() error = nil, want no such table")
+	}
+	parent.End()
+
+	tests := []struct {
+		name       string
+		wantAttrs  []attribute.KeyValue
+		wantStatus codes.Code
+	}{
+		{
+			name: "create greeters",
+			wantAttrs: []attribute.KeyValue{
+				attribute.String("db.system", "sqlite"),
+				attribute.String("db.operation", "CREATE"),
+				attribute.String("db.sql.table", "greeters"),
+				attribute.Int64("db.rows_affected", 1),
+			},
+		},
+		{
+			// a missing record is not an error of the statement
+			name: "query greeters",
+			wantAttrs: []attribute.KeyValue{
+				attribute.String("db.operation", "QUERY"),
+				attribute.Int64("db.rows_affected", 0),
+			},
+		},
+		{
+			name:       "raw",
+			wantAttrs:  []attribute.KeyValue{attribute.String("db.statement", "SELECT * FROM missing")},
+			wantStatus: codes.Error,
+		},
+	}
+	spans := exp.GetSpans()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := findSpan(t, spans, tt.name)
+			if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("parent = %s, want the span of the context %s", span.Parent.SpanID(), parent.SpanContext().SpanID())
+			}
+			if span.SpanKind != trace.SpanKindClient {
+				t.Errorf("kind = %s, want %s", span.SpanKind, trace.SpanKindClient)
+			}
+			for _, want := range tt.wantAttrs {
+				if !hasAttribute(span.Attributes, want) {
+					t.Errorf("attributes = %v, want %s=%s", span.Attributes, want.Key, want.Value.Emit())
+				}
+			}
+			if span.Status.Code != tt.wantStatus {
+				t.Errorf("status = %s, want %s", span.Status.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// findSpan returns the span named name, failing the test unless there is exactly one.
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	var found []tracetest.SpanStub
+	for _, s := range spans {
+		if s.Name == name {
+			found = append(found, s)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("found %d spans named %q, want 1", len(found), name)
+	}
+	return found[0]
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
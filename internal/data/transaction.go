@@ -0,0 +1,69 @@
+package data
+
+import (
+	"context"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+
+	"gorm.io/gorm"
+)
+
+var _ biz.Transaction = (*Data)(nil)
+
+type txKey struct{}
+
+type txHooksKey struct{}
+
+// txHooks are the functions to run once a transaction commits.
+type txHooks struct {
+	afterCommit []func()
+}
+
+// NewTransaction returns d as the biz.Transaction of the usecases.
+func NewTransaction(d *Data) biz.Transaction {
+	return d
+}
+
+// InTx implements biz.Transaction.
+// Repos pick the transaction up from ctx through DB and ReadDB. When ctx already carries a
+// transaction fn runs in a savepoint, so only its own changes are rolled back on failure.
+// The functions registered with afterCommit run once the outermost transaction commits.
+func (d *Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	db := d.gorm.WithContext(ctx)
+	if tx, ok := txFromContext(ctx); ok {
+		db = tx
+	}
+	hooks := &txHooks{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(context.WithValue(ctx, txKey{}, tx), txHooksKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	// the hooks of a savepoint wait for the transaction it is part of
+	if parent, ok := ctx.Value(txHooksKey{}).(*txHooks); ok {
+		parent.afterCommit = append(parent.afterCommit, hooks.afterCommit...)
+		return nil
+	}
+	for _, f := range hooks.afterCommit {
+		f()
+	}
+	return nil
+}
+
+// txFromContext returns the transaction carried by ctx, if any.
+func txFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// afterCommit runs fn once the transaction carried by ctx commits, or right away when ctx carries none.
+// fn never runs when the transaction, or the savepoint of ctx, is rolled back.
+func afterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(txHooksKey{}).(*txHooks); ok {
+		hooks.afterCommit = append(hooks.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,213 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+)
+
+var errTestRollback = errors.New("rollback")
+
+// hellos returns the hellos of every greeter in the database, outside of any transaction.
+func hellos(t *testing.T, repo biz.GreeterRepo) []string {
+	t.Helper()
+	gs, err := repo.ListAll(context.Background())
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	res := make([]string, 0, len(gs))
+	for _, g := range gs {
+		res = append(res, g.Hello)
+	}
+	return res
+}
+
+func TestDataInTx(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error
+		// wantErr is the error of InTx, wantHellos the greeters committed
+		wantErr    error
+		wantHellos []string
+	}{
+		{
+			name: "commit",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error {
+				g, err := repo.Save(ctx, &biz.Greeter{Hello: "a"})
+				if err != nil {
+					return err
+				}
+				_, err = repo.Update(ctx, &biz.Greeter{ID: g.ID, Hello: "b"})
+				return err
+			},
+			wantHellos: []string{"b"},
+		},
+		{
+			name: "rollback on error",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error {
+				if _, err := repo.Save(ctx, &biz.Greeter{Hello: "a"}); err != nil {
+					return err
+				}
+				return errTestRollback
+			},
+			wantErr:    errTestRollback,
+			wantHellos: []string{},
+		},
+		{
+			name: "rollback on a failed step",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error {
+				if _, err := repo.Save(ctx, &biz.Greeter{Hello: "a"}); err != nil {
+					return err
+				}
+				_, err := repo.Update(ctx, &biz.Greeter{ID: 1000, Hello: "b"})
+				return err
+			},
+			wantErr:    biz.ErrUserNotFound,
+			wantHellos: []string{},
+		},
+		{
+			name: "savepoint rolled back",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error {
+				if _, err := repo.Save(ctx, &biz.Greeter{Hello: "outer"}); err != nil {
+					return err
+				}
+				err := tx.InTx(ctx, func(ctx context.Context) error {
+					if _, err := repo.Save(ctx, &biz.Greeter{Hello: "inner"}); err != nil {
+						return err
+					}
+					return errTestRollback
+				})
+				if !errors.Is(err, errTestRollback) {
+					return err
+				}
+				// only the savepoint is rolled back, the outer transaction goes on
+				_, err = repo.Save(ctx, &biz.Greeter{Hello: "after"})
+				return err
+			},
+			wantHellos: []string{"outer", "after"},
+		},
+		{
+			name: "savepoint rolled back with the transaction",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error {
+				err := tx.InTx(ctx, func(ctx context.Context) error {
+					_, err := repo.Save(ctx, &biz.Greeter{Hello: "inner"})
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				return errTestRollback
+			},
+			wantErr:    errTestRollback,
+			wantHellos: []string{},
+		},
+		{
+			name: "reads see the writes of the transaction",
+			fn: func(ctx context.Context, tx biz.Transaction, repo biz.GreeterRepo) error {
+				g, err := repo.Save(ctx, &biz.Greeter{Hello: "a"})
+				if err != nil {
+					return err
+				}
+				if _, err := repo.FindByID(ctx, g.ID); err != nil {
+					return err
+				}
+				gs, err := repo.ListByHello(ctx, "a")
+				if err != nil {
+					return err
+				}
+				if len(gs) != 1 {
+					return errors.New("the greeter saved in the transaction is not listed")
+				}
+				return nil
+			},
+			wantHellos: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestData(t, nil)
+			tx, repo := NewTransaction(d), NewGreeterRepo(d, testLogger)
+
+			err := tx.InTx(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, tx, repo)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := hellos(t, repo); !slices.Equal(got, tt.wantHellos) {
+				t.Errorf("committed hellos = %v, want %v", got, tt.wantHellos)
+			}
+		})
+	}
+}
+
+func TestDataInTxPanic(t *testing.T) {
+	d := newTestData(t, nil)
+	tx, repo := NewTransaction(d), NewGreeterRepo(d, testLogger)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("InTx() did not propagate the panic")
+			}
+		}()
+		_ = tx.InTx(context.Background(), func(ctx context.Context) error {
+			if _, err := repo.Save(ctx, &biz.Greeter{Hello: "a"}); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	if got := hellos(t, repo); len(got) != 0 {
+		t.Errorf("committed hellos = %v after a panic, want none", got)
+	}
+}
+
+// failingUpdateRepo fails the update number failAt, counting from 1.
+type failingUpdateRepo struct {
+	biz.GreeterRepo
+	updates, failAt int
+}
+
+func (r *failingUpdateRepo) Update(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	r.updates++
+	if r.updates == r.failAt {
+		return nil, errTestRollback
+	}
+	return r.GreeterRepo.Update(ctx, g)
+}
+
+func TestGreeterUsecaseRenameGreeters(t *testing.T) {
+	tests := []struct {
+		name       string
+		failAt     int
+		wantErr    error
+		wantHellos []string
+	}{
+		{name: "every greeter renamed", wantHellos: []string{"b", "b", "c"}},
+		{name: "none renamed when an update fails", failAt: 2, wantErr: errTestRollback, wantHellos: []string{"a", "a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestData(t, nil)
+			repo := NewGreeterRepo(d, testLogger)
+			seedGreeters(t, repo, "a", "a", "c")
+			uc := biz.NewGreeterUsecase(&failingUpdateRepo{GreeterRepo: repo, failAt: tt.failAt}, NewTransaction(d), testLogger)
+
+			renamed, err := uc.RenameGreeters(context.Background(), "a", "b")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenameGreeters() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(renamed) != 2 {
+				t.Errorf("RenameGreeters() = %v, want the 2 greeters saying a", ids(renamed))
+			}
+			if got := hellos(t, repo); !slices.Equal(got, tt.wantHellos) {
+				t.Errorf("hellos = %v, want %v", got, tt.wantHellos)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCheckTimeout bounds every check run by Registry.Ready.
+const defaultCheckTimeout = 2 * time.Second
+
+// Status is the status of a check or of the whole service.
+type Status string
+
+// Statuses reported by the registry.
+const (
+	StatusOK           Status = "ok"
+	StatusFail         Status = "fail"
+	StatusShuttingDown Status = "shutting_down"
+)
+
+// Check checks a dependency and returns an error when it is unavailable.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a single check.
+type Result struct {
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of all the checks of a registry.
+type Report struct {
+	Status Status             `json:"status"`
+	Checks map[string]*Result `json:"checks,omitempty"`
+}
+
+// Ready reports whether the service can take traffic.
+func (r *Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// Registry holds the dependency checks that decide whether the service is ready.
+type Registry struct {
+	mu           sync.RWMutex
+	checks       map[string]Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		checks:  make(map[string]Check),
+		timeout: defaultCheckTimeout,
+	}
+}
+
+// Register adds a named check, replacing any check registered under the same name.
+func (r *Registry) Register(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check
+}
+
+// Names returns the names of the registered checks, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Shutdown marks the service as not ready, so that load balancers stop sending traffic
+// while it drains.
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+}
+
+// Ready runs every check concurrently and reports whether the service is ready.
+func (r *Registry) Ready(ctx context.Context) *Report {
+	r.mu.RLock()
+	checks := make(map[string]Check, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := &Report{Status: StatusOK, Checks: make(map[string]*Result, len(checks))}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			res := r.run(ctx, check)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = res
+			if res.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if r.shuttingDown.Load() {
+		report.Status = StatusShuttingDown
+	}
+	return report
+}
+
+// ReadyCheck runs the check registered under name. It returns false when no check is
+// registered under name, and reports StatusShuttingDown when the service is shutting down.
+func (r *Registry) ReadyCheck(ctx context.Context, name string) (*Result, bool) {
+	r.mu.RLock()
+	check, ok := r.checks[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	res := r.run(ctx, check)
+	if r.shuttingDown.Load() {
+		res.Status = StatusShuttingDown
+	}
+	return res, true
+}
+
+func (r *Registry) run(ctx context.Context, check Check) *Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	res := &Result{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
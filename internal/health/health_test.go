@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRegistryReady(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name       string
+		checks     map[string]Check
+		shutdown   bool
+		wantStatus Status
+		wantChecks map[string]Status
+		wantError  map[string]string
+	}{
+		{name: "no checks", wantStatus: StatusOK, wantChecks: map[string]Status{}},
+		{
+			name:       "every check passes",
+			checks:     map[string]Check{"database": ok, "redis": ok},
+			wantStatus: StatusOK,
+			wantChecks: map[string]Status{"database": StatusOK, "redis": StatusOK},
+		},
+		{
+			name:       "a check fails",
+			checks:     map[string]Check{"database": ok, "redis": fail},
+			wantStatus: StatusFail,
+			wantChecks: map[string]Status{"database": StatusOK, "redis": StatusFail},
+			wantError:  map[string]string{"redis": "connection refused"},
+		},
+		{
+			name:       "a check times out",
+			checks:     map[string]Check{"database": slow},
+			wantStatus: StatusFail,
+			wantChecks: map[string]Status{"database": StatusFail},
+			wantError:  map[string]string{"database": context.DeadlineExceeded.Error()},
+		},
+		{
+			name:       "shutting down",
+			checks:     map[string]Check{"database": ok},
+			shutdown:   true,
+			wantStatus: StatusShuttingDown,
+			wantChecks: map[string]Status{"database": StatusOK},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			r.timeout = 10 * time.Millisecond
+			for name, check := range tt.checks {
+				r.Register(name, check)
+			}
+			if tt.shutdown {
+				r.Shutdown()
+			}
+
+			report := r.Ready(context.Background())
+			if report.Status != tt.wantStatus || report.Ready() != (tt.wantStatus == StatusOK) {
+				t.Errorf("Ready() status = %s, ready = %v, want %s", report.Status, report.Ready(), tt.wantStatus)
+			}
+			if len(report.Checks) != len(tt.wantChecks) {
+				t.Errorf("Ready() checks = %d, want %d", len(report.Checks), len(tt.wantChecks))
+			}
+			for name, want := range tt.wantChecks {
+				res := report.Checks[name]
+				if res == nil || res.Status != want || res.Error != tt.wantError[name] {
+					t.Errorf("check %s = %+v, want %s %q", name, res, want, tt.wantError[name])
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryReadyCheck(t *testing.T) {
+	r := NewRegistry()
+	r.Register("database", func(context.Context) error { return nil })
+	r.Register("redis", func(context.Context) error { return errors.New("connection refused") })
+	// a check registered again is replaced
+	r.Register("redis", func(context.Context) error { return nil })
+
+	if got, want := r.Names(), []string{"database", "redis"}; !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	if res, ok := r.ReadyCheck(context.Background(), "redis"); !ok || res.Status != StatusOK {
+		t.Errorf("ReadyCheck(redis) = %+v, %v, want %s", res, ok, StatusOK)
+	}
+	if _, ok := r.ReadyCheck(context.Background(), "kafka"); ok {
+		t.Error("ReadyCheck(kafka) found a check that is not registered")
+	}
+	r.Shutdown()
+	if res, _ := r.ReadyCheck(context.Background(), "database"); res.Status != StatusShuttingDown {
+		t.Errorf("ReadyCheck(database) after Shutdown = %s, want %s", res.Status, StatusShuttingDown)
+	}
+}
@@ -0,0 +1,161 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// moduleKey is the key of the module of a logger, e.g. log.With(logger, "module", "data").
+const moduleKey = "module"
+
+// Override is a level set at runtime for all the loggers, or for the loggers of a module.
+// It takes precedence over the level of the config until it expires.
+type Override struct {
+	// Module of the loggers, empty for all the loggers.
+	Module string
+	Level  conf.LogLevel
+	// ExpiresAt is the time at which the override is removed, zero when it is not.
+	ExpiresAt time.Time
+}
+
+func (o Override) expired(now time.Time) bool {
+	return !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt)
+}
+
+// Level is the minimum level of a logger, which can be changed while the logger is in use:
+// by the config on reload, and by overrides for all the loggers or a module of the loggers.
+type Level struct {
+	v atomic.Int32
+
+	mu sync.Mutex
+	// overrides is replaced on every change, so that it is read without locking
+	overrides atomic.Pointer[map[string]Override]
+}
+
+// NewLevel creates a Level set to level.
+func NewLevel(level conf.LogLevel) *Level {
+	l := &Level{}
+	l.Set(level)
+	return l
+}
+
+// Set changes the minimum level of the config to level.
+func (l *Level) Set(level conf.LogLevel) {
+	l.v.Store(int32(level))
+}
+
+// Get returns the minimum level of the config.
+func (l *Level) Get() conf.LogLevel {
+	return conf.LogLevel(l.v.Load())
+}
+
+// Enabled reports whether messages at level are logged by the loggers of module.
+// The override of the module comes first, then the override of all the loggers, then the config.
+func (l *Level) Enabled(level log.Level, module string) bool {
+	min := l.Get()
+	if overrides := l.overrides.Load(); overrides != nil {
+		now := time.Now()
+		if o, ok := (*overrides)[""]; ok && !o.expired(now) {
+			min = o.Level
+		}
+		if o, ok := (*overrides)[module]; ok && module != "" && !o.expired(now) {
+			min = o.Level
+		}
+	}
+	return level >= convertLogLevel(min)
+}
+
+// Override sets the level of the loggers of module, or of all the loggers when module is empty,
+// for ttl, or until it is reset when ttl is 0.
+func (l *Level) Override(module string, level conf.LogLevel, ttl time.Duration) Override {
+	o := Override{Module: module, Level: level}
+	if ttl > 0 {
+		o.ExpiresAt = time.Now().Add(ttl)
+	}
+	l.update(func(overrides map[string]Override) {
+		overrides[module] = o
+	})
+	return o
+}
+
+// Reset removes the override of module, or of all the loggers when module is empty.
+func (l *Level) Reset(module string) {
+	l.update(func(overrides map[string]Override) {
+		delete(overrides, module)
+	})
+}
+
+// Overrides returns the overrides that have not expired, sorted by module.
+func (l *Level) Overrides() []Override {
+	l.update(func(map[string]Override) {})
+
+	var overrides []Override
+	if current := l.overrides.Load(); current != nil {
+		for _, o := range *current {
+			overrides = append(overrides, o)
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Module < overrides[j].Module
+	})
+	return overrides
+}
+
+// update replaces the overrides by a copy, changed by fn, without the expired ones.
+func (l *Level) update(fn func(map[string]Override)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	next := make(map[string]Override)
+	if current := l.overrides.Load(); current != nil {
+		for module, o := range *current {
+			if !o.expired(now) {
+				next[module] = o
+			}
+		}
+	}
+	fn(next)
+	l.overrides.Store(&next)
+}
+
+// ParseLevel parses a level name, e.g. DEBUG.
+func ParseLevel(name string) (conf.LogLevel, error) {
+	v, ok := conf.LogLevel_value[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+	return conf.LogLevel(v), nil
+}
+
+// levelLogger logs the entries enabled by its level for the module of the entry.
+type levelLogger struct {
+	logger log.Logger
+	level  *Level
+}
+
+// Log implements log.Logger.
+func (l *levelLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if !l.level.Enabled(level, module(keyvals)) {
+		return nil
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+// module returns the module of the keyvals of a log entry, or "" if it has none.
+func module(keyvals []interface{}) string {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == moduleKey {
+			m, _ := keyvals[i+1].(string)
+			return m
+		}
+	}
+	return ""
+}
@@ -0,0 +1,195 @@
+package log
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// expire makes the override of module expire, as if its TTL had passed.
+func expire(l *Level, module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	next := make(map[string]Override)
+	for m, o := range *l.overrides.Load() {
+		if m == module {
+			o.ExpiresAt = time.Now().Add(-time.Second)
+		}
+		next[m] = o
+	}
+	l.overrides.Store(&next)
+}
+
+func TestLevelEnabled(t *testing.T) {
+	type override struct {
+		module  string
+		level   conf.LogLevel
+		ttl     time.Duration
+		expired bool
+	}
+	tests := []struct {
+		name      string
+		config    conf.LogLevel
+		overrides []override
+		level     log.Level
+		module    string
+		want      bool
+	}{
+		{name: "config", config: conf.LogLevel_INFO, level: log.LevelInfo, want: true},
+		{name: "below the config", config: conf.LogLevel_INFO, level: log.LevelDebug, want: false},
+		{
+			name:      "all the loggers",
+			config:    conf.LogLevel_INFO,
+			overrides: []override{{level: conf.LogLevel_DEBUG}},
+			level:     log.LevelDebug,
+			module:    "data",
+			want:      true,
+		},
+		{
+			name:      "module",
+			config:    conf.LogLevel_INFO,
+			overrides: []override{{module: "data", level: conf.LogLevel_DEBUG}},
+			level:     log.LevelDebug,
+			module:    "data",
+			want:      true,
+		},
+		{
+			name:      "other module",
+			config:    conf.LogLevel_INFO,
+			overrides: []override{{module: "data", level: conf.LogLevel_DEBUG}},
+			level:     log.LevelDebug,
+			module:    "server",
+			want:      false,
+		},
+		{
+			name:      "module over all the loggers",
+			config:    conf.LogLevel_DEBUG,
+			overrides: []override{{level: conf.LogLevel_DEBUG}, {module: "data", level: conf.LogLevel_ERROR}},
+			level:     log.LevelWarn,
+			module:    "data",
+			want:      false,
+		},
+		{
+			name:      "override above the config",
+			config:    conf.LogLevel_DEBUG,
+			overrides: []override{{level: conf.LogLevel_WARN}},
+			level:     log.LevelInfo,
+			want:      false,
+		},
+		{
+			name:      "until its ttl",
+			config:    conf.LogLevel_INFO,
+			overrides: []override{{module: "data", level: conf.LogLevel_DEBUG, ttl: time.Hour}},
+			level:     log.LevelDebug,
+			module:    "data",
+			want:      true,
+		},
+		{
+			name:      "back to the config once expired",
+			config:    conf.LogLevel_INFO,
+			overrides: []override{{module: "data", level: conf.LogLevel_DEBUG, ttl: time.Hour, expired: true}},
+			level:     log.LevelDebug,
+			module:    "data",
+			want:      false,
+		},
+		{
+			name:   "back to all the loggers once the module override expired",
+			config: conf.LogLevel_ERROR,
+			overrides: []override{
+				{level: conf.LogLevel_WARN},
+				{module: "data", level: conf.LogLevel_DEBUG, ttl: time.Hour, expired: true},
+			},
+			level:  log.LevelWarn,
+			module: "data",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLevel(tt.config)
+			for _, o := range tt.overrides {
+				l.Override(o.module, o.level, o.ttl)
+				if o.expired {
+					expire(l, o.module)
+				}
+			}
+			if got := l.Enabled(tt.level, tt.module); got != tt.want {
+				t.Errorf("Enabled(%s, %q) = %v, want %v", tt.level, tt.module, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelOverrides(t *testing.T) {
+	l := NewLevel(conf.LogLevel_INFO)
+	before := time.Now()
+	l.Override("server", conf.LogLevel_WARN, 0)
+	l.Override("data", conf.LogLevel_DEBUG, time.Minute)
+	l.Override("", conf.LogLevel_ERROR, time.Minute)
+
+	modules := func() []string {
+		var ms []string
+		for _, o := range l.Overrides() {
+			ms = append(ms, o.Module)
+		}
+		return ms
+	}
+	if got, want := modules(), []string{"", "data", "server"}; !slices.Equal(got, want) {
+		t.Fatalf("Overrides() modules = %q, want %q", got, want)
+	}
+	for _, o := range l.Overrides() {
+		if o.Module == "server" && !o.ExpiresAt.IsZero() {
+			t.Errorf("ExpiresAt of an override without ttl = %s, want zero", o.ExpiresAt)
+		}
+		if o.Module == "data" && o.ExpiresAt.Before(before.Add(time.Minute)) {
+			t.Errorf("ExpiresAt of an override for a minute = %s, want after %s", o.ExpiresAt, before.Add(time.Minute))
+		}
+	}
+
+	// an expired override is removed, the level of the config is restored
+	expire(l, "data")
+	if got, want := modules(), []string{"", "server"}; !slices.Equal(got, want) {
+		t.Errorf("Overrides() modules after data expired = %q, want %q", got, want)
+	}
+	l.Reset("")
+	l.Reset("server")
+	if got := l.Overrides(); len(got) != 0 {
+		t.Errorf("Overrides() after Reset = %v, want none", got)
+	}
+	if !l.Enabled(log.LevelInfo, "server") || l.Enabled(log.LevelDebug, "data") {
+		t.Error("Enabled() after Reset does not follow the config")
+	}
+
+	// the level of the config changes on reload under the overrides
+	l.Override("data", conf.LogLevel_DEBUG, 0)
+	l.Set(conf.LogLevel_ERROR)
+	if !l.Enabled(log.LevelDebug, "data") || l.Enabled(log.LevelWarn, "server") {
+		t.Error("Enabled() after Set does not follow the override of data and the new config")
+	}
+}
+
+// messages records the last value of every entry logged.
+type messages []string
+
+func (m *messages) Log(level log.Level, keyvals ...interface{}) error {
+	*m = append(*m, keyvals[len(keyvals)-1].(string))
+	return nil
+}
+
+func TestLevelLogger(t *testing.T) {
+	var logged messages
+	l := &levelLogger{logger: &logged, level: NewLevel(conf.LogLevel_INFO)}
+	l.level.Override("data", conf.LogLevel_DEBUG, 0)
+
+	_ = l.Log(log.LevelDebug, moduleKey, "data", "msg", "data debug")
+	_ = l.Log(log.LevelDebug, moduleKey, "server", "msg", "server debug")
+	_ = l.Log(log.LevelDebug, "msg", "debug without module")
+	_ = l.Log(log.LevelInfo, moduleKey, "server", "msg", "server info")
+	if want := (messages{"data debug", "server info"}); !slices.Equal(logged, want) {
+		t.Errorf("logged = %q, want %q", logged, want)
+	}
+}
@@ -1,8 +1,6 @@
 package log
 
 import (
-	"os"
-
 	"github.com/adam-xu-mantle/go-template/internal/conf"
 
 	kzap "github.com/go-kratos/kratos/contrib/log/zap/v2"
@@ -30,24 +28,41 @@ func convertLogLevel(level conf.LogLevel) log.Level {
 }
 
 // NewLogger creates a new logger.
-func NewLogger(c *conf.Log) log.Logger {
-	var filter *log.Filter
-	logLevel := convertLogLevel(c.Level)
+func NewLogger(c *conf.Log) (log.Logger, error) {
+	return NewLoggerWithLevel(c, NewLevel(c.GetLevel()))
+}
 
-	if c.Format == conf.FormatType_JSON {
-		encoderConfig := zapcore.EncoderConfig{
-			MessageKey:  "msg",
-			LineEnding:  zapcore.DefaultLineEnding,
-			EncodeLevel: zapcore.CapitalLevelEncoder,
-		}
-		logEncoder := zapcore.NewJSONEncoder(encoderConfig)
-		core := zapcore.NewCore(logEncoder, zapcore.AddSync(os.Stdout), zap.DebugLevel)
-		zlogger := zap.New(core).WithOptions()
-		logger := kzap.NewLogger(zlogger)
-		filter = log.NewFilter(logger, log.FilterLevel(logLevel))
-	} else {
-		filter = log.NewFilter(log.NewStdLogger(os.Stdout), log.FilterLevel(logLevel))
+// NewLoggerWithLevel creates a new logger that logs the messages enabled by level to the outputs of c.
+// Entries carry their timestamp, level and, unless disabled, the file and line of their caller,
+// and their secrets are masked unless redaction is disabled.
+func NewLoggerWithLevel(c *conf.Log, level *Level) (log.Logger, error) {
+	encoder := newEncoder(c)
+	var cores []zapcore.Core
+	for _, o := range outputs(c) {
+		cores = append(cores, zapcore.NewCore(encoder, newWriter(o), outputLevel(o)))
+	}
+	core := zapcore.NewTee(cores...)
+	if s := c.GetSampling(); s != nil {
+		core = newSampler(core, s)
 	}
 
-	return filter
+	var opts []zap.Option
+	if c.GetStacktrace() {
+		opts = append(opts, zap.AddStacktrace(zapcore.ErrorLevel))
+	}
+	var logger log.Logger = kzap.NewLogger(zap.New(core, opts...))
+
+	if !c.GetRedaction().GetDisable() {
+		redactor, err := NewRedactor(c.GetRedaction())
+		if err != nil {
+			return nil, err
+		}
+		logger = &redactLogger{logger: logger, redactor: redactor}
+	}
+	logger = &levelLogger{logger: logger, level: level}
+	if !c.GetDisableCaller() {
+		// the caller is resolved by Kratos, as zap would report the Kratos helpers
+		logger = log.With(logger, "caller", log.DefaultCaller)
+	}
+	return logger, nil
 }
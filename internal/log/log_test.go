@@ -0,0 +1,100 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// readEntries returns the level and message of every JSON entry of the log file at path.
+func readEntries(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e struct {
+			Level string `json:"level"`
+			Msg   string `json:"msg"`
+		}
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("entry %s is not JSON: %v", s.Bytes(), err)
+		}
+		entries = append(entries, e.Level+" "+e.Msg)
+	}
+	return entries
+}
+
+func TestNewLoggerOutputs(t *testing.T) {
+	dir := t.TempDir()
+	level := func(l conf.LogLevel) *conf.LogLevel { return &l }
+	logger, err := NewLogger(&conf.Log{
+		Level: conf.LogLevel_DEBUG,
+		Outputs: []*conf.Log_Output{
+			{Path: filepath.Join(dir, "all.log")},
+			{Path: filepath.Join(dir, "app.log"), MaxLevel: level(conf.LogLevel_INFO)},
+			{Path: filepath.Join(dir, "error.log"), MinLevel: level(conf.LogLevel_WARN)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	h := log.NewHelper(logger)
+	h.Debug("debug")
+	h.Info("info")
+	h.Warn("warn")
+	h.Error("error")
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"all.log", []string{"DEBUG debug", "INFO info", "WARN warn", "ERROR error"}},
+		{"app.log", []string{"DEBUG debug", "INFO info"}},
+		{"error.log", []string{"WARN warn", "ERROR error"}},
+	}
+	for _, tt := range tests {
+		if got := readEntries(t, filepath.Join(dir, tt.file)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestNewLoggerSampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(&conf.Log{
+		Outputs:  []*conf.Log_Output{{Path: path}},
+		Sampling: &conf.Log_Sampling{Tick: durationpb.New(time.Hour), Initial: 2, Thereafter: 3},
+	})
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	h := log.NewHelper(logger)
+	for range 8 {
+		h.Info("repeated")
+	}
+	h.Info("other")
+
+	// the first 2 entries of a message in the tick, then every 3rd: the 5th and the 8th
+	want := []string{"INFO repeated", "INFO repeated", "INFO repeated", "INFO repeated", "INFO other"}
+	if got := readEntries(t, path); !slices.Equal(got, want) {
+		t.Errorf("entries = %q, want %q", got, want)
+	}
+}
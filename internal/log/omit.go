@@ -0,0 +1,39 @@
+package log
+
+import (
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// OmitEmpty returns a logger that drops the pairs of keys whose value is empty, e.g. the request
+// and trace IDs bound with log.With to the logs written outside a request.
+// Loggers created with log.With on top of it have their valuers resolved before the pairs are dropped.
+func OmitEmpty(logger log.Logger, keys ...string) log.Logger {
+	return &omitEmptyLogger{logger: logger, keys: keys}
+}
+
+type omitEmptyLogger struct {
+	logger log.Logger
+	keys   []string
+}
+
+// Log implements log.Logger.
+func (l *omitEmptyLogger) Log(level log.Level, keyvals ...interface{}) error {
+	kept := make([]interface{}, 0, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 < len(keyvals) && keyvals[i+1] == "" && l.omitted(keyvals[i]) {
+			continue
+		}
+		kept = append(kept, keyvals[i:min(i+2, len(keyvals))]...)
+	}
+	return l.logger.Log(level, kept...)
+}
+
+// omitted reports whether the pairs of key are dropped when their value is empty.
+func (l *omitEmptyLogger) omitted(key interface{}) bool {
+	for _, k := range l.keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// entries records the keyvals of every entry logged.
+type entries [][]interface{}
+
+func (e *entries) Log(_ log.Level, keyvals ...interface{}) error {
+	*e = append(*e, keyvals)
+	return nil
+}
+
+func TestOmitEmpty(t *testing.T) {
+	type requestKey struct{}
+	requestID := func(ctx context.Context) interface{} {
+		id, _ := ctx.Value(requestKey{}).(string)
+		return id
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		keyvals []interface{}
+		want    []interface{}
+	}{
+		{
+			name:    "in a request",
+			ctx:     context.WithValue(context.Background(), requestKey{}, "abc"),
+			keyvals: []interface{}{"msg", "handled"},
+			want:    []interface{}{"request_id", "abc", "msg", "handled"},
+		},
+		{
+			name:    "outside a request",
+			ctx:     context.Background(),
+			keyvals: []interface{}{"msg", "shutting down"},
+			want:    []interface{}{"msg", "shutting down"},
+		},
+		{
+			name:    "other keys",
+			ctx:     context.Background(),
+			keyvals: []interface{}{"reason", "", "msg", ""},
+			want:    []interface{}{"reason", "", "msg", ""},
+		},
+		{
+			name:    "key without value",
+			ctx:     context.Background(),
+			keyvals: []interface{}{"msg", "odd", "request_id"},
+			want:    []interface{}{"msg", "odd", "request_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged entries
+			logger := log.With(OmitEmpty(&logged, "request_id"), "request_id", log.Valuer(requestID))
+			_ = log.WithContext(tt.ctx, logger).Log(log.LevelInfo, tt.keyvals...)
+			if want := (entries{tt.want}); !reflect.DeepEqual(logged, want) {
+				t.Errorf("logged %v, want %v", logged, want)
+			}
+		})
+	}
+}
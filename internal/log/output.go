@@ -0,0 +1,149 @@
+package log
+
+import (
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Paths of the outputs that are standard streams rather than files.
+const (
+	outputStdout = "stdout"
+	outputStderr = "stderr"
+)
+
+// Defaults of the file outputs and of the sampling of entries.
+const (
+	defaultMaxSizeMB          = 100
+	defaultSamplingTick       = time.Second
+	defaultSamplingInitial    = 100
+	defaultSamplingThereafter = 100
+)
+
+// zapLevel converts conf.LogLevel to the zap level of the entries logged by Kratos at that level.
+func zapLevel(level conf.LogLevel) zapcore.Level {
+	switch level {
+	case conf.LogLevel_DEBUG:
+		return zapcore.DebugLevel
+	case conf.LogLevel_WARN:
+		return zapcore.WarnLevel
+	case conf.LogLevel_ERROR:
+		return zapcore.ErrorLevel
+	case conf.LogLevel_FATAL:
+		return zapcore.FatalLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// outputs returns the outputs of c, stdout when it has none.
+func outputs(c *conf.Log) []*conf.Log_Output {
+	if len(c.GetOutputs()) == 0 {
+		return []*conf.Log_Output{{Path: outputStdout}}
+	}
+	return c.GetOutputs()
+}
+
+// outputLevel returns the levels written to o. The minimum level of the logger is applied by its Level.
+func outputLevel(o *conf.Log_Output) zapcore.LevelEnabler {
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		if o.MinLevel != nil && l < zapLevel(o.GetMinLevel()) {
+			return false
+		}
+		if o.MaxLevel != nil && l > zapLevel(o.GetMaxLevel()) {
+			return false
+		}
+		return true
+	})
+}
+
+// newWriter returns the writer of o: a standard stream or a file rotated by lumberjack.
+func newWriter(o *conf.Log_Output) zapcore.WriteSyncer {
+	switch o.GetPath() {
+	case outputStdout:
+		return zapcore.Lock(os.Stdout)
+	case outputStderr:
+		return zapcore.Lock(os.Stderr)
+	}
+
+	maxSize := defaultMaxSizeMB
+	if o.GetMaxSizeMb() > 0 {
+		maxSize = int(o.GetMaxSizeMb())
+	}
+	var maxAge int
+	if o.GetMaxAge() != nil {
+		maxAge = int(math.Ceil(o.GetMaxAge().AsDuration().Hours() / 24))
+	}
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   o.GetPath(),
+		MaxSize:    maxSize,
+		MaxAge:     maxAge,
+		MaxBackups: int(o.GetMaxBackups()),
+		Compress:   o.GetCompress(),
+	})
+}
+
+// newEncoder returns the encoder of the format of c.
+func newEncoder(c *conf.Log) zapcore.Encoder {
+	cfg := zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeTime:     timeEncoder(c.GetTimeFormat()),
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	if c.GetFormat() == conf.FormatType_CONSOLE {
+		if c.GetColor() {
+			cfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+		return zapcore.NewConsoleEncoder(cfg)
+	}
+	return zapcore.NewJSONEncoder(cfg)
+}
+
+// timeEncoder returns the encoder of the timestamps in format, see conf.Log.
+func timeEncoder(format string) zapcore.TimeEncoder {
+	switch strings.ToUpper(format) {
+	case "", "RFC3339":
+		return zapcore.RFC3339TimeEncoder
+	case "RFC3339NANO":
+		return zapcore.RFC3339NanoTimeEncoder
+	case "ISO8601":
+		return zapcore.ISO8601TimeEncoder
+	case "EPOCH":
+		return zapcore.EpochTimeEncoder
+	case "EPOCH_MILLIS":
+		return zapcore.EpochMillisTimeEncoder
+	case "EPOCH_NANOS":
+		return zapcore.EpochNanosTimeEncoder
+	default:
+		return zapcore.TimeEncoderOfLayout(format)
+	}
+}
+
+// newSampler samples the entries of core as configured by s.
+func newSampler(core zapcore.Core, s *conf.Log_Sampling) zapcore.Core {
+	tick := defaultSamplingTick
+	if s.GetTick() != nil {
+		tick = s.GetTick().AsDuration()
+	}
+	initial := defaultSamplingInitial
+	if s.GetInitial() > 0 {
+		initial = int(s.GetInitial())
+	}
+	thereafter := defaultSamplingThereafter
+	if s.GetThereafter() > 0 {
+		thereafter = int(s.GetThereafter())
+	}
+	return zapcore.NewSamplerWithOptions(core, tick, initial, thereafter)
+}
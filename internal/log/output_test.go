@@ -0,0 +1,70 @@
+package log
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestOutputLevel(t *testing.T) {
+	level := func(l conf.LogLevel) *conf.LogLevel { return &l }
+	tests := []struct {
+		name   string
+		output *conf.Log_Output
+		want   []zapcore.Level
+	}{
+		{"every level", &conf.Log_Output{}, []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel}},
+		{"min level", &conf.Log_Output{MinLevel: level(conf.LogLevel_WARN)}, []zapcore.Level{zapcore.WarnLevel, zapcore.ErrorLevel}},
+		{"max level", &conf.Log_Output{MaxLevel: level(conf.LogLevel_INFO)}, []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel}},
+		{"min and max level", &conf.Log_Output{MinLevel: level(conf.LogLevel_INFO), MaxLevel: level(conf.LogLevel_WARN)}, []zapcore.Level{zapcore.InfoLevel, zapcore.WarnLevel}},
+		// the zero value of a level is DEBUG, and is not the same as leaving it unset
+		{"max level debug", &conf.Log_Output{MaxLevel: level(conf.LogLevel_DEBUG)}, []zapcore.Level{zapcore.DebugLevel}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabler := outputLevel(tt.output)
+			var got []zapcore.Level
+			for _, l := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+				if enabler.Enabled(l) {
+					got = append(got, l)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("enabled levels = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeEncoder(t *testing.T) {
+	ts := time.Date(2024, 3, 9, 14, 5, 6, 123456789, time.UTC)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", `"2024-03-09T14:05:06Z"`},
+		{"rfc3339", `"2024-03-09T14:05:06Z"`},
+		{"RFC3339NANO", `"2024-03-09T14:05:06.123456789Z"`},
+		{"ISO8601", `"2024-03-09T14:05:06.123Z"`},
+		{"epoch", `1709993106.1234567`},
+		{"EPOCH_MILLIS", `1709993106123.4568`},
+		{"EPOCH_NANOS", `1709993106123456789`},
+		{"2006-01-02 15:04:05.000", `"2024-03-09 14:05:06.123"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buf, err := newEncoder(&conf.Log{TimeFormat: tt.format}).EncodeEntry(zapcore.Entry{Time: ts, Message: "m"}, nil)
+			if err != nil {
+				t.Fatalf("EncodeEntry() error = %v", err)
+			}
+			if got := buf.String(); !strings.Contains(got, `"ts":`+tt.want+`,`) {
+				t.Errorf("entry = %s, want the timestamp %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// redactedMask replaces the secrets in the entries.
+const redactedMask = "******"
+
+// defaultRedactedKeys are the keys of the secrets masked by default, see conf.Log_Redaction.
+var defaultRedactedKeys = []string{
+	"password", "passwd", "pwd", "secret", "token", "authorization", "cookie", "api_key", "apikey", "private_key",
+}
+
+// defaultRedactedPatterns are the secrets masked by default: emails, bearer tokens, JWTs and
+// the passwords of URL DSNs, e.g. postgres://app:secret@db/app.
+var defaultRedactedPatterns = []string{
+	`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	`(?i)bearer\s+([A-Za-z0-9._~+/-]+=*)`,
+	`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`,
+	`://[^:/@\s]+:([^@/\s]+)@`,
+}
+
+// Redactor masks the secrets in log entries: the values of secret keys, and the secrets
+// found by patterns in the other values, e.g. query parameters or the literals of SQL queries.
+type Redactor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a Redactor of the default keys and patterns and those of c.
+func NewRedactor(c *conf.Log_Redaction) (*Redactor, error) {
+	r := &Redactor{}
+	for _, k := range append(defaultRedactedKeys, c.GetKeys()...) {
+		r.keys = append(r.keys, strings.ToLower(k))
+	}
+
+	// the values of the keys in key=value pairs, query parameters, JSON and SQL comparisons,
+	// e.g. ?token=abc, "password": "abc" or "password" = 'abc'. The auth scheme of an unquoted value
+	// is kept, e.g. Authorization: Bearer ******.
+	quoted := make([]string, len(r.keys))
+	for i, k := range r.keys {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	keyValue := `(?i)[\w.-]*(?:` + strings.Join(quoted, "|") + `)[\w.-]*["'` + "`" + `]?\s*[=:]\s*(?:'([^']*)'|"([^"]*)"|(?:(?:bearer|basic|token)\s+)?([^\s&,;)"']+))`
+
+	for _, p := range append([]string{keyValue}, append(defaultRedactedPatterns, c.GetPatterns()...)...) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// secretKey reports whether key is the key of a secret.
+func (r *Redactor) secretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns s with the secrets found by the patterns masked.
+func (r *Redactor) Redact(s string) string {
+	for _, re := range r.patterns {
+		s = redactMatches(re, s)
+	}
+	return s
+}
+
+// redactMatches masks the matches of re in s, or only their capture groups when re has any.
+func redactMatches(re *regexp.Regexp, s string) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if len(m) == 2 {
+			b.WriteString(s[last:m[0]])
+			b.WriteString(redactedMask)
+			last = m[1]
+			continue
+		}
+		for g := 2; g < len(m); g += 2 {
+			if m[g] < 0 {
+				continue
+			}
+			b.WriteString(s[last:m[g]])
+			b.WriteString(redactedMask)
+			last = m[g+1]
+		}
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// redactValue returns v with its secrets masked, or masked entirely when key is the key of a secret.
+func (r *Redactor) redactValue(key string, v interface{}) interface{} {
+	if r.secretKey(key) {
+		return redactedMask
+	}
+
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		return v
+	}
+	if redacted := r.Redact(s); redacted != s {
+		return redacted
+	}
+	return v
+}
+
+// redactLogger masks the secrets in the entries with its redactor.
+type redactLogger struct {
+	logger   log.Logger
+	redactor *Redactor
+}
+
+// Log implements log.Logger.
+func (l *redactLogger) Log(level log.Level, keyvals ...interface{}) error {
+	redacted := make([]interface{}, len(keyvals))
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, _ := keyvals[i].(string)
+		redacted[i], redacted[i+1] = keyvals[i], l.redactor.redactValue(key, keyvals[i+1])
+	}
+	if len(keyvals)%2 != 0 {
+		redacted[len(keyvals)-1] = keyvals[len(keyvals)-1]
+	}
+	return l.logger.Log(level, redacted...)
+}
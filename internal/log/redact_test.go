@@ -0,0 +1,45 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+)
+
+func TestRedactorRedact(t *testing.T) {
+	r, err := NewRedactor(&conf.Log_Redaction{Keys: []string{"ssn"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bearer header", "Authorization: Bearer abc.def.ghi", "Authorization: Bearer ******"},
+		{"basic header", "authorization: Basic dXNlcjpwYXNz", "authorization: Basic ******"},
+		{"token scheme", "Authorization=token ghp_abc123", "Authorization=token ******"},
+		{"bearer alone", "sent Bearer abc.def.ghi upstream", "sent Bearer ****** upstream"},
+		{"query parameter", "/login?user=bob&token=abc123&next=/", "/login?user=bob&token=******&next=/"},
+		{"json", `{"password": "hunter2", "name": "bob"}`, `{"password": "******", "name": "bob"}`},
+		{"sql literal", `UPDATE users SET "password" = 'hunter2' WHERE id = 1`, `UPDATE users SET "password" = '******' WHERE id = 1`},
+		{"configured key", "ssn=123-45-6789", "ssn=******"},
+		{"dsn", "postgres://app:hunter2@db:5432/app", "postgres://app:******@db:5432/app"},
+		{"email", "sent to bob@example.com", "sent to ******"},
+		{"no secret", "GET /helloworld/bob 200", "GET /helloworld/bob 200"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Redact(tt.in); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRedactorInvalidPattern(t *testing.T) {
+	if _, err := NewRedactor(&conf.Log_Redaction{Patterns: []string{"("}}); err == nil {
+		t.Fatal("NewRedactor() with an invalid pattern succeeded")
+	}
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+// SLO are the objectives the alert rules are generated for.
+type SLO struct {
+	// ErrorRatio is the ratio of failed requests to an endpoint over 5 minutes above which it alerts, e.g. 0.05.
+	ErrorRatio float64
+	// LatencyThreshold is the duration in seconds requests should complete in, e.g. 0.5.
+	// It must be a bucket of the request durations.
+	LatencyThreshold float64
+	// LatencyObjective is the ratio of requests that should complete in LatencyThreshold, e.g. 0.99.
+	LatencyObjective float64
+}
+
+// burnRateWindows are the multiwindow, multi-burn-rate alerts of the latency SLO: the error budget
+// burning at rate over both the long and the short window alerts with severity.
+var burnRateWindows = []struct {
+	long, short string
+	rate        float64
+	severity    string
+}{
+	// 2% of a 30 days budget in 1 hour
+	{long: "1h", short: "5m", rate: 14.4, severity: "critical"},
+	// 5% of a 30 days budget in 6 hours
+	{long: "6h", short: "30m", rate: 6, severity: "warning"},
+}
+
+type ruleFile struct {
+	Groups []ruleGroup `yaml:"groups"`
+}
+
+type ruleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []rule `yaml:"rules"`
+}
+
+type rule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// AlertRules returns the Prometheus alert rules, in YAML, of the error ratio and the latency SLO burn rate
+// of every endpoint of e, as recorded by a Metricer configured by c. The alerts are per job.
+func AlertRules(c *conf.Metrics, service string, e Endpoints, slo SLO) ([]byte, error) {
+	if slo.ErrorRatio <= 0 || slo.ErrorRatio >= 1 {
+		return nil, fmt.Errorf("error ratio %v must be between 0 and 1", slo.ErrorRatio)
+	}
+	if slo.LatencyObjective <= 0 || slo.LatencyObjective >= 1 {
+		return nil, fmt.Errorf("latency objective %v must be between 0 and 1", slo.LatencyObjective)
+	}
+	// the ratio of fast requests is read from the bucket of the threshold
+	requestBuckets := buckets(c.GetRequestBuckets(), prometheus.DefBuckets)
+	if !slices.Contains(requestBuckets, slo.LatencyThreshold) {
+		return nil, fmt.Errorf("latency threshold %vs is not a request duration bucket, expected one of %v", slo.LatencyThreshold, requestBuckets)
+	}
+
+	ns := namespace(c)
+	httpRules := make([]rule, 0, 3*len(e.Routes))
+	for _, route := range e.Routes {
+		httpRules = append(httpRules, endpointRules(ns+"_http_requests_total", ns+"_http_request_duration_seconds",
+			"HTTP", "route", route, `status_class="5xx"`, slo)...)
+	}
+	grpcRules := make([]rule, 0, 3*len(e.GRPCMethods))
+	for _, method := range e.GRPCMethods {
+		grpcRules = append(grpcRules, endpointRules(ns+"_grpc_requests_total", ns+"_grpc_request_duration_seconds",
+			"GRPC", "method", method, fmt.Sprintf("code=~%q", grpcErrorCodes), slo)...)
+	}
+
+	return yaml.Marshal(&ruleFile{Groups: []ruleGroup{
+		{Name: service + "-http", Rules: httpRules},
+		{Name: service + "-grpc", Rules: grpcRules},
+	}})
+}
+
+// endpointRules returns the alert rules of the endpoint whose requests have the label value,
+// recorded by counter and histogram. Requests matching errors are errors.
+func endpointRules(counter, histogram, protocol, label, value, errors string, slo SLO) []rule {
+	endpoint := fmt.Sprintf("%s=%q", label, value)
+	by := "job, " + label
+
+	rules := []rule{{
+		Alert: protocol + "HighErrorRatio",
+		Expr: fmt.Sprintf("sum by (%[1]s) (rate(%[2]s{%[3]s, %[4]s}[5m]))\n  / sum by (%[1]s) (rate(%[2]s{%[3]s}[5m]))\n> %[5]s",
+			by, counter, endpoint, errors, formatFloat(slo.ErrorRatio)),
+		For:    "5m",
+		Labels: map[string]string{"severity": "critical"},
+		Annotations: map[string]string{
+			"summary": fmt.Sprintf("%s %s fails more than %s of its requests", protocol, value, formatPercent(slo.ErrorRatio)),
+			"description": fmt.Sprintf("{{ $value | humanizePercentage }} of the requests of {{ $labels.job }} to %s %s failed over the last 5 minutes.",
+				protocol, value),
+		},
+	}}
+
+	budget := 1 - slo.LatencyObjective
+	slow := func(window string) string {
+		return fmt.Sprintf("(1 - sum by (%[1]s) (rate(%[2]s_bucket{%[3]s, %[4]s}[%[5]s])) / sum by (%[1]s) (rate(%[2]s_count{%[3]s}[%[5]s])))",
+			by, histogram, endpoint, leMatcher(slo.LatencyThreshold), window)
+	}
+	for _, w := range burnRateWindows {
+		threshold := formatFloat(w.rate * budget)
+		rules = append(rules, rule{
+			Alert:  protocol + "LatencySLOBurn",
+			Expr:   fmt.Sprintf("%s > %s\nand\n%s > %s", slow(w.long), threshold, slow(w.short), threshold),
+			For:    "2m",
+			Labels: map[string]string{"severity": w.severity, "window": w.long},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("%s %s burns its latency error budget %v times too fast", protocol, value, w.rate),
+				"description": fmt.Sprintf("More than %s of the requests of {{ $labels.job }} to %s %s took over %ss over the last %s, for an objective of %s.",
+					formatPercent(w.rate*budget), protocol, value, formatFloat(slo.LatencyThreshold), w.long, formatPercent(slo.LatencyObjective)),
+			},
+		})
+	}
+	return rules
+}
+
+// leMatcher returns the matcher of the bucket of the upper bound v. Prometheus may store integer
+// bounds either as written by the client, e.g. 1, or normalized, e.g. 1.0.
+func leMatcher(v float64) string {
+	le := strconv.FormatFloat(v, 'g', -1, 64)
+	if strings.ContainsAny(le, ".e") {
+		return fmt.Sprintf("le=%q", le)
+	}
+	return fmt.Sprintf(`le=~"%s(\\.0)?"`, le)
+}
+
+// formatFloat formats v without the rounding errors of the computed thresholds, e.g. 0.144 for 14.4*0.01.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', 10, 64)
+}
+
+// formatPercent formats the ratio v as a percentage, e.g. 5%.
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v*100, 'g', 4, 64) + "%"
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"gopkg.in/yaml.v3"
+)
+
+// testSLO are the objectives the alert rules of the tests are generated for.
+var testSLO = SLO{ErrorRatio: 0.05, LatencyThreshold: 0.5, LatencyObjective: 0.99}
+
+func TestAlertRules(t *testing.T) {
+	b, err := AlertRules(&conf.Metrics{Namespace: "app"}, "go-template", testEndpoints, testSLO)
+	if err != nil {
+		t.Fatalf("AlertRules() error = %v", err)
+	}
+	var f ruleFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		t.Fatalf("AlertRules() is not YAML: %v", err)
+	}
+
+	// an error ratio alert and two latency burn rate alerts per endpoint
+	tests := []struct {
+		group     string
+		wantRules int
+	}{
+		{"go-template-http", 3 * len(testEndpoints.Routes)},
+		{"go-template-grpc", 3 * len(testEndpoints.GRPCMethods)},
+	}
+	if len(f.Groups) != len(tests) {
+		t.Fatalf("groups = %d, want %d", len(f.Groups), len(tests))
+	}
+	for i, tt := range tests {
+		if g := f.Groups[i]; g.Name != tt.group || len(g.Rules) != tt.wantRules {
+			t.Errorf("group %d = %s with %d rules, want %s with %d", i, g.Name, len(g.Rules), tt.group, tt.wantRules)
+		}
+	}
+
+	errorRatio := f.Groups[0].Rules[3]
+	wantExpr := `sum by (job, route) (rate(app_http_requests_total{route="/helloworld/:name", status_class="5xx"}[5m]))
+  / sum by (job, route) (rate(app_http_requests_total{route="/helloworld/:name"}[5m]))
+> 0.05`
+	if errorRatio.Alert != "HTTPHighErrorRatio" || errorRatio.Expr != wantExpr {
+		t.Errorf("rule = %s: %s, want HTTPHighErrorRatio: %s", errorRatio.Alert, errorRatio.Expr, wantExpr)
+	}
+
+	// the budget of 1% burning 14.4 and 6 times too fast
+	for i, want := range []struct{ severity, threshold string }{{"critical", "> 0.144"}, {"warning", "> 0.06"}} {
+		burn := f.Groups[1].Rules[1+i]
+		if burn.Alert != "GRPCLatencySLOBurn" || burn.Labels["severity"] != want.severity || !strings.Contains(burn.Expr, want.threshold) {
+			t.Errorf("rule = %s %v: %s, want GRPCLatencySLOBurn %s %s", burn.Alert, burn.Labels, burn.Expr, want.severity, want.threshold)
+		}
+		if !strings.Contains(burn.Expr, `app_grpc_request_duration_seconds_bucket{method="/helloworld.v1.Greeter/SayHello", le="0.5"}`) {
+			t.Errorf("rule expr = %s, want the bucket of the threshold", burn.Expr)
+		}
+	}
+}
+
+func TestAlertRulesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *conf.Metrics
+		slo     SLO
+		wantErr string
+	}{
+		{"error ratio", nil, SLO{ErrorRatio: 1, LatencyThreshold: 0.5, LatencyObjective: 0.99}, "error ratio 1 must be between 0 and 1"},
+		{"latency objective", nil, SLO{ErrorRatio: 0.05, LatencyThreshold: 0.5}, "latency objective 0 must be between 0 and 1"},
+		{"threshold not a bucket", nil, SLO{ErrorRatio: 0.05, LatencyThreshold: 0.3, LatencyObjective: 0.99}, "latency threshold 0.3s is not a request duration bucket"},
+		{"threshold not a configured bucket", &conf.Metrics{RequestBuckets: []float64{0.1, 1}}, testSLO, "latency threshold 0.5s is not a request duration bucket, expected one of [0.1 1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AlertRules(tt.c, "go-template", testEndpoints, tt.slo)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("AlertRules() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLeMatcher(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want string
+	}{
+		{0.5, `le="0.5"`},
+		{1, `le=~"1(\\.0)?"`},
+		{1e-05, `le="1e-05"`},
+	}
+	for _, tt := range tests {
+		if got := leMatcher(tt.v); got != tt.want {
+			t.Errorf("leMatcher(%v) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+)
+
+// Endpoints are the endpoints of a server that dashboards and alert rules are generated for.
+type Endpoints struct {
+	// Routes are the HTTP route templates, e.g. /helloworld/:name.
+	Routes []string
+	// GRPCMethods are the full gRPC methods, e.g. /helloworld.v1.Greeter/SayHello.
+	GRPCMethods []string
+}
+
+// grpcErrorCodes are the gRPC codes counted as server errors, the others being caused by the client.
+const grpcErrorCodes = "Unknown|DeadlineExceeded|Unimplemented|Internal|Unavailable|DataLoss"
+
+// Grafana dashboard layout: panels are laid out three per row on the 24 columns grid.
+const (
+	grafanaSchemaVersion = 39
+	panelWidth           = 8
+	panelHeight          = 8
+)
+
+type grafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	Timezone      string            `json:"timezone"`
+	Editable      bool              `json:"editable"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTime       `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []*grafanaPanel   `json:"panels"`
+}
+
+type grafanaTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name       string             `json:"name"`
+	Label      string             `json:"label"`
+	Type       string             `json:"type"`
+	Query      string             `json:"query"`
+	Datasource *grafanaDatasource `json:"datasource,omitempty"`
+	Refresh    int                `json:"refresh,omitempty"`
+	IncludeAll bool               `json:"includeAll,omitempty"`
+	AllValue   string             `json:"allValue,omitempty"`
+	Multi      bool               `json:"multi,omitempty"`
+}
+
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaPanel struct {
+	ID          int                 `json:"id"`
+	Type        string              `json:"type"`
+	Title       string              `json:"title"`
+	GridPos     grafanaGridPos      `json:"gridPos"`
+	Collapsed   bool                `json:"collapsed,omitempty"`
+	Datasource  *grafanaDatasource  `json:"datasource,omitempty"`
+	FieldConfig *grafanaFieldConfig `json:"fieldConfig,omitempty"`
+	Targets     []grafanaTarget     `json:"targets,omitempty"`
+	// Panels are the panels of a collapsed row.
+	Panels []*grafanaPanel `json:"panels,omitempty"`
+}
+
+type grafanaGridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults  grafanaFieldDefaults `json:"defaults"`
+	Overrides []struct{}           `json:"overrides"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type grafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+	// Exemplar shows the exemplars of the series, linking to their traces.
+	Exemplar bool `json:"exemplar,omitempty"`
+}
+
+// dashboardBuilder lays out the panels of a dashboard from top to bottom, three per row of the grid.
+type dashboardBuilder struct {
+	ns     string
+	panels []*grafanaPanel
+	id     int
+	// y is the top of the current row of the grid, and col the number of panels since the last dashboard row.
+	y   int
+	col int
+	// row is the collapsed row the panels are added to, nil for the top level.
+	row *grafanaPanel
+}
+
+// red is the rate, errors and duration of requests recorded by a counter and a histogram.
+type red struct {
+	counter   string
+	histogram string
+	// matchers select the requests, e.g. of a route.
+	matchers string
+	// errors select the failed requests.
+	errors string
+	// requestsBy are the labels the rate is split by.
+	requestsBy string
+	// by are the labels the error ratio and the durations are split by.
+	by string
+}
+
+// Dashboard returns the Grafana dashboard, in JSON, of the rate, errors and duration of the requests
+// to the endpoints of e, as recorded by a Metricer configured by c, of the database statements
+// and of the configuration reloads. Every endpoint has a collapsed row of its own.
+func Dashboard(c *conf.Metrics, service string, e Endpoints) ([]byte, error) {
+	b := &dashboardBuilder{ns: namespace(c)}
+	httpErrors := `status_class="5xx"`
+	grpcErrors := fmt.Sprintf("code=~%q", grpcErrorCodes)
+
+	b.addRow("HTTP", false)
+	b.addRED(red{
+		counter: b.metric("http_requests_total"), histogram: b.metric("http_request_duration_seconds"),
+		errors: httpErrors, requestsBy: "route", by: "route",
+	})
+	for _, route := range e.Routes {
+		b.addRow("HTTP "+route, true)
+		b.addRED(red{
+			counter: b.metric("http_requests_total"), histogram: b.metric("http_request_duration_seconds"),
+			matchers: fmt.Sprintf("route=%q", route), errors: httpErrors, requestsBy: "method, status_class", by: "method",
+		})
+	}
+
+	b.addRow("gRPC", false)
+	b.addRED(red{
+		counter: b.metric("grpc_requests_total"), histogram: b.metric("grpc_request_duration_seconds"),
+		errors: grpcErrors, requestsBy: "method", by: "method",
+	})
+	for _, method := range e.GRPCMethods {
+		b.addRow("gRPC "+method, true)
+		b.addRED(red{
+			counter: b.metric("grpc_requests_total"), histogram: b.metric("grpc_request_duration_seconds"),
+			matchers: fmt.Sprintf("method=%q", method), errors: grpcErrors, requestsBy: "code", by: "job",
+		})
+	}
+
+	b.addRow("Database", false)
+	duration := b.metric("db_query_duration_seconds")
+	b.addPanel("Statements", "ops", "{{operation}} {{table}}", false,
+		fmt.Sprintf(`sum by (operation, table) (rate(%s_count{%s}[$__rate_interval]))`, duration, b.selector("")))
+	b.addPanel("Failed statements", "ops", "{{operation}} {{table}}", false,
+		fmt.Sprintf(`sum by (operation, table) (rate(%s{%s}[$__rate_interval]))`, b.metric("db_query_errors_total"), b.selector("")))
+	b.addPanel("Statement duration p99", "s", "{{operation}} {{table}}", true,
+		fmt.Sprintf(`histogram_quantile(0.99, sum by (operation, table, le) (rate(%s_bucket{%s}[$__rate_interval])))`, duration, b.selector("")))
+	b.addPanel("Connections in use", "short", "{{db_name}}", false,
+		fmt.Sprintf(`sum by (db_name) (go_sql_in_use_connections{%s})`, b.selector("")))
+	b.addPanel("Waits for a connection", "ops", "{{db_name}}", false,
+		fmt.Sprintf(`sum by (db_name) (rate(go_sql_wait_count_total{%s}[$__rate_interval]))`, b.selector("")))
+	b.addPanel("Config reloads", "short", "{{result}}", false,
+		fmt.Sprintf(`sum by (result) (increase(%s{%s}[$__rate_interval]))`, b.metric("config_reloads_total"), b.selector("")))
+
+	d := &grafanaDashboard{
+		UID:           dashboardUID(service),
+		Title:         service,
+		Tags:          []string{service, "generated"},
+		Timezone:      "browser",
+		Editable:      true,
+		SchemaVersion: grafanaSchemaVersion,
+		Refresh:       "30s",
+		Time:          grafanaTime{From: "now-1h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+			{
+				Name:       "job",
+				Label:      "Job",
+				Type:       "query",
+				Query:      fmt.Sprintf("label_values(%s, job)", b.metric("http_requests_total")),
+				Datasource: prometheusDatasource,
+				Refresh:    2, // on time range change
+				IncludeAll: true,
+				AllValue:   ".+",
+				Multi:      true,
+			},
+		}},
+		Panels: b.panels,
+	}
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// prometheusDatasource is the data source selected by the datasource variable of the dashboard.
+var prometheusDatasource = &grafanaDatasource{Type: "prometheus", UID: "${datasource}"}
+
+// dashboardUID returns the UID of the dashboard of service, at most 40 characters as Grafana requires.
+func dashboardUID(service string) string {
+	uid := service + "-red"
+	if len(uid) > 40 {
+		uid = uid[:40]
+	}
+	return uid
+}
+
+// metric returns the full name of the metric name.
+func (b *dashboardBuilder) metric(name string) string {
+	return b.ns + "_" + name
+}
+
+// selector returns the label matchers of the series of the selected jobs, and of matchers if any.
+func (b *dashboardBuilder) selector(matchers string) string {
+	if matchers == "" {
+		return `job=~"$job"`
+	}
+	return `job=~"$job", ` + matchers
+}
+
+// addRow starts a new row. The panels of a collapsed row are hidden until it is expanded.
+func (b *dashboardBuilder) addRow(title string, collapsed bool) {
+	if b.col > 0 {
+		b.y += panelHeight
+	}
+	b.id++
+	row := &grafanaPanel{
+		ID:        b.id,
+		Type:      "row",
+		Title:     title,
+		GridPos:   grafanaGridPos{X: 0, Y: b.y, W: 3 * panelWidth, H: 1},
+		Collapsed: collapsed,
+	}
+	b.panels = append(b.panels, row)
+	b.y++
+	b.col = 0
+	b.row = nil
+	if collapsed {
+		b.row = row
+	}
+}
+
+// addRED adds the request rate, error ratio and duration percentiles panels of r.
+func (b *dashboardBuilder) addRED(r red) {
+	all := b.selector(r.matchers)
+	failed := b.selector(r.matchers) + ", " + r.errors
+
+	b.addPanel("Requests", "reqps", legendFormat(r.requestsBy), false,
+		fmt.Sprintf(`sum by (%s) (rate(%s{%s}[$__rate_interval]))`, r.requestsBy, r.counter, all))
+	b.addPanel("Error ratio", "percentunit", legendFormat(r.by), false,
+		fmt.Sprintf(`sum by (%[1]s) (rate(%[2]s{%[3]s}[$__rate_interval])) / sum by (%[1]s) (rate(%[2]s{%[4]s}[$__rate_interval]))`,
+			r.by, r.counter, failed, all))
+
+	var exprs []string
+	for _, q := range []string{"0.5", "0.95", "0.99"} {
+		exprs = append(exprs, fmt.Sprintf(`histogram_quantile(%s, sum by (%s, le) (rate(%s_bucket{%s}[$__rate_interval])))`, q, r.by, r.histogram, all))
+	}
+	panel := b.addPanel("Duration", "s", "", true, exprs...)
+	for i, q := range []string{"p50", "p95", "p99"} {
+		panel.Targets[i].LegendFormat = q + " " + legendFormat(r.by)
+	}
+}
+
+// addPanel adds a time series panel of the queries exprs, in unit, to the current row.
+// exemplar shows the exemplars of the series, linking to their traces.
+func (b *dashboardBuilder) addPanel(title, unit, legend string, exemplar bool, exprs ...string) *grafanaPanel {
+	if b.col > 0 && b.col%3 == 0 {
+		b.y += panelHeight
+	}
+	b.id++
+	panel := &grafanaPanel{
+		ID:          b.id,
+		Type:        "timeseries",
+		Title:       title,
+		GridPos:     grafanaGridPos{X: (b.col % 3) * panelWidth, Y: b.y, W: panelWidth, H: panelHeight},
+		Datasource:  prometheusDatasource,
+		FieldConfig: &grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: unit}, Overrides: []struct{}{}},
+	}
+	for i, expr := range exprs {
+		panel.Targets = append(panel.Targets, grafanaTarget{RefID: string(rune('A' + i)), Expr: expr, LegendFormat: legend, Exemplar: exemplar})
+	}
+	b.col++
+
+	if b.row != nil {
+		b.row.Panels = append(b.row.Panels, panel)
+	} else {
+		b.panels = append(b.panels, panel)
+	}
+	return panel
+}
+
+// legendFormat returns the legend of the series split by the labels by, e.g. {{method}} {{status_class}}.
+func legendFormat(by string) string {
+	var labels []string
+	for _, label := range strings.Split(by, ",") {
+		labels = append(labels, "{{"+strings.TrimSpace(label)+"}}")
+	}
+	return strings.Join(labels, " ")
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+)
+
+// testEndpoints are the endpoints the dashboards and alert rules of the tests are generated for.
+var testEndpoints = Endpoints{
+	Routes:      []string{"/graphql", "/helloworld/:name"},
+	GRPCMethods: []string{"/helloworld.v1.Greeter/SayHello"},
+}
+
+func TestDashboard(t *testing.T) {
+	b, err := Dashboard(&conf.Metrics{Namespace: "app"}, "go-template", testEndpoints)
+	if err != nil {
+		t.Fatalf("Dashboard() error = %v", err)
+	}
+	var d grafanaDashboard
+	if err := json.Unmarshal(b, &d); err != nil {
+		t.Fatalf("Dashboard() is not JSON: %v", err)
+	}
+
+	if d.UID != "go-template-red" || d.Title != "go-template" {
+		t.Errorf("uid, title = %q, %q, want %q, %q", d.UID, d.Title, "go-template-red", "go-template")
+	}
+	var vars []string
+	for _, v := range d.Templating.List {
+		vars = append(vars, v.Name)
+	}
+	if want := []string{"datasource", "job"}; !slices.Equal(vars, want) {
+		t.Errorf("variables = %v, want %v", vars, want)
+	}
+
+	// every endpoint has a collapsed row of its rate, errors and duration
+	var rows []string
+	ids := make(map[int]bool)
+	var exprs []string
+	var walk func(panels []*grafanaPanel)
+	walk = func(panels []*grafanaPanel) {
+		for _, p := range panels {
+			if ids[p.ID] {
+				t.Errorf("panel %q has the id %d of another panel", p.Title, p.ID)
+			}
+			ids[p.ID] = true
+			if p.Type == "row" {
+				rows = append(rows, p.Title)
+				if p.Collapsed && len(p.Panels) != 3 {
+					t.Errorf("row %q has %d panels, want the requests, errors and duration", p.Title, len(p.Panels))
+				}
+			}
+			for _, target := range p.Targets {
+				exprs = append(exprs, target.Expr)
+			}
+			walk(p.Panels)
+		}
+	}
+	walk(d.Panels)
+	wantRows := []string{"HTTP", "HTTP /graphql", "HTTP /helloworld/:name", "gRPC", "gRPC /helloworld.v1.Greeter/SayHello", "Database"}
+	if !slices.Equal(rows, wantRows) {
+		t.Errorf("rows = %q, want %q", rows, wantRows)
+	}
+
+	wantExprs := []string{
+		`sum by (method, status_class) (rate(app_http_requests_total{job=~"$job", route="/helloworld/:name"}[$__rate_interval]))`,
+		`sum by (code) (rate(app_grpc_requests_total{job=~"$job", method="/helloworld.v1.Greeter/SayHello"}[$__rate_interval]))`,
+		`sum by (result) (increase(app_config_reloads_total{job=~"$job"}[$__rate_interval]))`,
+	}
+	for _, want := range wantExprs {
+		if !slices.Contains(exprs, want) {
+			t.Errorf("no panel queries %s", want)
+		}
+	}
+	for _, expr := range exprs {
+		if strings.Contains(expr, "default_") {
+			t.Errorf("query %s does not use the namespace of the config", expr)
+		}
+	}
+}
+
+func TestDashboardUID(t *testing.T) {
+	tests := []struct {
+		service string
+		want    string
+	}{
+		{"api", "api-red"},
+		{strings.Repeat("s", 50), strings.Repeat("s", 40)},
+	}
+	for _, tt := range tests {
+		if got := dashboardUID(tt.service); got != tt.want {
+			t.Errorf("dashboardUID(%q) = %q, want %q", tt.service, got, tt.want)
+		}
+	}
+}
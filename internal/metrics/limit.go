@@ -0,0 +1,39 @@
+package metrics
+
+import "sync"
+
+// labelLimiter bounds the number of distinct values of a label, so that unexpected values
+// cannot create an unbounded number of series: once max values are recorded, any other
+// value is replaced by the overflow value.
+type labelLimiter struct {
+	max      int
+	overflow string
+
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+func newLabelLimiter(max int, overflow string) *labelLimiter {
+	return &labelLimiter{max: max, overflow: overflow, values: make(map[string]struct{})}
+}
+
+// value returns v if it is one of the recorded values or can be recorded, and the overflow value otherwise.
+func (l *labelLimiter) value(v string) string {
+	l.mu.RLock()
+	_, ok := l.values[v]
+	l.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.values[v]; ok {
+		return v
+	}
+	if len(l.values) >= l.max {
+		return l.overflow
+	}
+	l.values[v] = struct{}{}
+	return v
+}
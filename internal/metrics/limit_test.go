@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestLabelLimiter(t *testing.T) {
+	l := newLabelLimiter(2, OverflowRoute)
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"/a", "/a"},
+		{"/b", "/b"},
+		{"/c", OverflowRoute},
+		// the recorded values are kept once the limit is reached
+		{"/a", "/a"},
+		{"/b", "/b"},
+		{"/d", OverflowRoute},
+		{"/c", OverflowRoute},
+	}
+	for _, tt := range tests {
+		if got := l.value(tt.value); got != tt.want {
+			t.Errorf("value(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLabelLimiterConcurrent(t *testing.T) {
+	const max = 10
+	l := newLabelLimiter(max, OverflowRoute)
+
+	var (
+		mu       sync.Mutex
+		recorded = make(map[string]bool)
+		wg       sync.WaitGroup
+	)
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := strconv.Itoa(i)
+			if got := l.value(v); got != OverflowRoute {
+				mu.Lock()
+				recorded[got] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(recorded) != max {
+		t.Errorf("recorded %d values, want %d", len(recorded), max)
+	}
+}
@@ -1,57 +1,265 @@
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/google/wire"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProviderSet is metrics providers.
+var ProviderSet = wire.NewSet(NewMetricer)
+
+// defaultNamespace is used when conf.Metrics leaves namespace unset.
+const defaultNamespace = "default"
+
+// defaultMaxRoutes is used when conf.Metrics leaves max_routes unset.
+const defaultMaxRoutes = 200
+
+// Routes recorded in place of the route of a request.
+const (
+	// UnmatchedRoute is the route of the requests that match no route.
+	UnmatchedRoute = "unmatched"
+	// OverflowRoute is the route of the requests to routes beyond the maximum number of routes.
+	OverflowRoute = "overflow"
+)
+
+// otherMethod is the method recorded for requests with a non-standard method.
+const otherMethod = "OTHER"
+
+// httpMethods are the methods recorded as is.
+var httpMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true,
+	http.MethodDelete: true, http.MethodConnect: true, http.MethodOptions: true, http.MethodTrace: true,
+}
+
+// defaultDBBuckets are the buckets of the database query durations when conf.Metrics leaves them unset.
+var defaultDBBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
 
 // Metricer is the interface for business metrics.
 type Metricer interface {
-	RecordMetricRequests(path, method string) func()
+	// RecordHTTPRequest records an HTTP request to route, a route template such as /helloworld/:name
+	// or UnmatchedRoute, served with status in elapsed. The status is recorded by class, e.g. 2xx.
+	RecordHTTPRequest(ctx context.Context, method, route string, status int, elapsed time.Duration)
+	// RecordGRPCRequest records a gRPC call of the full method completed with code in elapsed.
+	RecordGRPCRequest(ctx context.Context, method, code string, elapsed time.Duration)
+	// RecordDBQuery records a database statement, failed when err is not nil.
+	RecordDBQuery(ctx context.Context, operation, table string, err error, elapsed time.Duration)
+	// RegisterDBStats registers the connection pool stats of db, e.g. open and idle connections, as name.
+	RegisterDBStats(name string, db *sql.DB) error
 }
 
 type metricer struct {
-	requestCount    *prometheus.CounterVec
-	requestDuration *prometheus.HistogramVec
+	reg    prometheus.Registerer
+	routes *labelLimiter
+
+	httpRequestCount    *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	grpcRequestCount    *prometheus.CounterVec
+	grpcRequestDuration *prometheus.HistogramVec
+	dbQueryDuration     *prometheus.HistogramVec
+	dbQueryErrors       *prometheus.CounterVec
 }
 
-// NewMetricer creates a new Metricer.
-func NewMetricer(name, subname string) Metricer {
-	if name == "" {
-		name = "default"
-	}
+// NewRegistry creates a registry with the Go runtime and process collectors.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
 
-	m := metricer{
-		requestCount: prometheus.NewCounterVec(
+// NewMetricer creates a new Metricer, whose metrics are registered with reg.
+// Metrics already registered with reg, e.g. by another Metricer, are shared.
+func NewMetricer(c *conf.Metrics, reg prometheus.Registerer) (Metricer, error) {
+	ns := namespace(c)
+	requestBuckets := buckets(c.GetRequestBuckets(), prometheus.DefBuckets)
+	m := &metricer{
+		reg:    reg,
+		routes: newLabelLimiter(int(intOrDefault(c.GetMaxRoutes(), defaultMaxRoutes)), OverflowRoute),
+		httpRequestCount: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
-				Namespace: name,
-				Subsystem: subname,
+				Namespace: ns,
 				Name:      "http_requests_total",
 				Help:      "Total number of HTTP requests",
 			},
-			[]string{"path", "method"},
+			[]string{"route", "method", "status_class"},
 		),
-		requestDuration: prometheus.NewHistogramVec(
+		httpRequestDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
-				Namespace: name,
-				Subsystem: subname,
+				Namespace: ns,
 				Name:      "http_request_duration_seconds",
 				Help:      "HTTP request duration in seconds",
-				Buckets:   prometheus.DefBuckets,
+				Buckets:   requestBuckets,
+			},
+			[]string{"route", "method", "status_class"},
+		),
+		grpcRequestCount: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: ns,
+				Name:      "grpc_requests_total",
+				Help:      "Total number of gRPC calls",
+			},
+			[]string{"method", "code"},
+		),
+		grpcRequestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: ns,
+				Name:      "grpc_request_duration_seconds",
+				Help:      "gRPC call duration in seconds",
+				Buckets:   requestBuckets,
+			},
+			[]string{"method", "code"},
+		),
+		dbQueryDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: ns,
+				Name:      "db_query_duration_seconds",
+				Help:      "Database statement duration in seconds",
+				Buckets:   buckets(c.GetDbBuckets(), defaultDBBuckets),
+			},
+			[]string{"operation", "table"},
+		),
+		dbQueryErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: ns,
+				Name:      "db_query_errors_total",
+				Help:      "Total number of failed database statements",
 			},
-			[]string{"path", "method"},
+			[]string{"operation", "table"},
 		),
 	}
 
-	// Register metrics with Prometheus default registry
-	prometheus.MustRegister(m.requestCount)
-	prometheus.MustRegister(m.requestDuration)
+	if err := errors.Join(
+		register(reg, &m.httpRequestCount),
+		register(reg, &m.httpRequestDuration),
+		register(reg, &m.grpcRequestCount),
+		register(reg, &m.grpcRequestDuration),
+		register(reg, &m.dbQueryDuration),
+		register(reg, &m.dbQueryErrors),
+	); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
-	return &m
+// RecordHTTPRequest implements Metricer.
+func (m *metricer) RecordHTTPRequest(ctx context.Context, method, route string, status int, elapsed time.Duration) {
+	if !httpMethods[method] {
+		method = otherMethod
+	}
+	// unmatched requests share one route that does not count towards the maximum
+	if route != UnmatchedRoute {
+		route = m.routes.value(route)
+	}
+	class := strconv.Itoa(status/100) + "xx"
+	ex := exemplar(ctx)
+	inc(m.httpRequestCount.WithLabelValues(route, method, class), ex)
+	observe(m.httpRequestDuration.WithLabelValues(route, method, class), elapsed.Seconds(), ex)
+}
+
+// RecordGRPCRequest implements Metricer.
+func (m *metricer) RecordGRPCRequest(ctx context.Context, method, code string, elapsed time.Duration) {
+	ex := exemplar(ctx)
+	inc(m.grpcRequestCount.WithLabelValues(method, code), ex)
+	observe(m.grpcRequestDuration.WithLabelValues(method, code), elapsed.Seconds(), ex)
+}
+
+// RecordDBQuery implements Metricer.
+func (m *metricer) RecordDBQuery(ctx context.Context, operation, table string, err error, elapsed time.Duration) {
+	ex := exemplar(ctx)
+	observe(m.dbQueryDuration.WithLabelValues(operation, table), elapsed.Seconds(), ex)
+	if err != nil {
+		inc(m.dbQueryErrors.WithLabelValues(operation, table), ex)
+	}
+}
+
+// exemplar returns the exemplar labels linking a sample to the trace of ctx,
+// or nil when the trace is not sampled and so cannot be looked up.
+func exemplar(ctx context.Context) prometheus.Labels {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{"trace_id": sc.TraceID().String()}
+}
+
+// inc increments c, with the exemplar ex unless it is nil.
+func inc(c prometheus.Counter, ex prometheus.Labels) {
+	if adder, ok := c.(prometheus.ExemplarAdder); ok && ex != nil {
+		adder.AddWithExemplar(1, ex)
+		return
+	}
+	c.Inc()
+}
+
+// observe observes v in o, with the exemplar ex unless it is nil.
+func observe(o prometheus.Observer, v float64, ex prometheus.Labels) {
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && ex != nil {
+		eo.ObserveWithExemplar(v, ex)
+		return
+	}
+	o.Observe(v)
+}
+
+// RegisterDBStats implements Metricer. The stats of a database reopened under the same name replace the previous ones.
+func (m *metricer) RegisterDBStats(name string, db *sql.DB) error {
+	c := collectors.NewDBStatsCollector(db, name)
+	err := m.reg.Register(c)
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		m.reg.Unregister(are.ExistingCollector)
+		err = m.reg.Register(c)
+	}
+	return err
+}
+
+// register registers the collector *c with reg. When an identical collector is already
+// registered, *c is replaced by it so that both record the same series.
+func register[C prometheus.Collector](reg prometheus.Registerer, c *C) error {
+	err := reg.Register(*c)
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(C); ok {
+			*c = existing
+			return nil
+		}
+	}
+	return err
+}
+
+// namespace returns the namespace of the metrics configured by c.
+func namespace(c *conf.Metrics) string {
+	if c.GetNamespace() != "" {
+		return c.GetNamespace()
+	}
+	return defaultNamespace
+}
+
+// intOrDefault returns v, or def when v is not positive.
+func intOrDefault(v, def int32) int32 {
+	if v > 0 {
+		return v
+	}
+	return def
 }
 
-// RecordMetricRequests records a metric request.
-func (m *metricer) RecordMetricRequests(path, method string) func() {
-	m.requestCount.WithLabelValues(path, method).Inc()
-	timer := prometheus.NewTimer(m.requestDuration.WithLabelValues(path, method))
-	return func() {
-		timer.ObserveDuration()
+// buckets returns the configured buckets sorted, as Prometheus requires, or defaults when there are none.
+func buckets(configured, defaults []float64) []float64 {
+	if len(configured) == 0 {
+		return defaults
 	}
+	b := append([]float64(nil), configured...)
+	sort.Float64s(b)
+	return b
 }
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordHTTPRequest(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := NewMetricer(&conf.Metrics{MaxRoutes: 1}, reg)
+	if err != nil {
+		t.Fatalf("NewMetricer() error = %v", err)
+	}
+
+	tests := []struct {
+		method, route string
+		status        int
+		// wantLabels are the route, method and status_class the request is recorded with
+		wantLabels [3]string
+	}{
+		{"GET", "/helloworld/:name", 200, [3]string{"/helloworld/:name", "GET", "2xx"}},
+		{"GET", "/helloworld/:name", 204, [3]string{"/helloworld/:name", "GET", "2xx"}},
+		{"POST", "/helloworld/:name", 301, [3]string{"/helloworld/:name", "POST", "3xx"}},
+		{"GET", "/helloworld/:name", 404, [3]string{"/helloworld/:name", "GET", "4xx"}},
+		{"DELETE", "/helloworld/:name", 499, [3]string{"/helloworld/:name", "DELETE", "4xx"}},
+		{"GET", "/helloworld/:name", 500, [3]string{"/helloworld/:name", "GET", "5xx"}},
+		{"GET", "/helloworld/:name", 503, [3]string{"/helloworld/:name", "GET", "5xx"}},
+		{"PROPFIND", "/helloworld/:name", 200, [3]string{"/helloworld/:name", "OTHER", "2xx"}},
+		// unmatched requests do not count towards the maximum number of routes
+		{"GET", UnmatchedRoute, 404, [3]string{UnmatchedRoute, "GET", "4xx"}},
+		{"GET", "/graphql", 200, [3]string{OverflowRoute, "GET", "2xx"}},
+	}
+	for _, tt := range tests {
+		m.RecordHTTPRequest(context.Background(), tt.method, tt.route, tt.status, time.Millisecond)
+	}
+
+	want := make(map[[3]string]float64)
+	for _, tt := range tests {
+		want[tt.wantLabels]++
+	}
+	counter := m.(*metricer).httpRequestCount
+	if got := testutil.CollectAndCount(counter); got != len(want) {
+		t.Errorf("series = %d, want %d", got, len(want))
+	}
+	for labels, n := range want {
+		if got := testutil.ToFloat64(counter.WithLabelValues(labels[:]...)); got != n {
+			t.Errorf("requests %v = %v, want %v", labels, got, n)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type reloadMetricer struct {
+	reloadCount *prometheus.CounterVec
+}
+
+var _ conf.ReloadMetricer = (*reloadMetricer)(nil)
+
+// NewReloadMetricer creates the metrics of the configuration reloads, registered with reg.
+func NewReloadMetricer(c *conf.Metrics, reg prometheus.Registerer) (conf.ReloadMetricer, error) {
+	reloadCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace(c),
+			Name:      "config_reloads_total",
+			Help:      "Total number of configuration reloads by result",
+		},
+		[]string{"result"},
+	)
+	if err := register(reg, &reloadCount); err != nil {
+		return nil, err
+	}
+	return &reloadMetricer{reloadCount: reloadCount}, nil
+}
+
+// RecordReload records a configuration reload with its result.
+func (m *reloadMetricer) RecordReload(result string) {
+	m.reloadCount.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,80 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header, and the gRPC metadata key, that carries the request ID
+// of a call. An incoming ID is kept, and the ID of every call is returned in the response.
+const Header = "X-Request-ID"
+
+// maxLength bounds the length of the incoming IDs that are kept.
+const maxLength = 128
+
+type contextKey struct{}
+
+// New returns a new request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// NewContext returns a copy of ctx that carries the request ID id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Valuer returns a log valuer of the request ID of the context of the log,
+// e.g. the context passed to log.Helper.WithContext.
+func Valuer() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		if ctx == nil {
+			return ""
+		}
+		return FromContext(ctx)
+	}
+}
+
+// Resolve returns incoming if it is a valid request ID, or a new request ID.
+// Incoming IDs that are too long or contain non printable characters are replaced.
+func Resolve(incoming string) string {
+	if incoming == "" || len(incoming) > maxLength {
+		return New()
+	}
+	for i := 0; i < len(incoming); i++ {
+		if incoming[i] < 0x21 || incoming[i] > 0x7e {
+			return New()
+		}
+	}
+	return incoming
+}
+
+// Server is a server middleware that injects the request ID of the call into its context
+// and returns it in the reply header.
+func Server() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			id := FromContext(ctx)
+			if id == "" {
+				id = Resolve(tr.RequestHeader().Get(Header))
+				ctx = NewContext(ctx, id)
+			}
+			tr.ReplyHeader().Set(Header, id)
+			return handler(ctx, req)
+		}
+	}
+}
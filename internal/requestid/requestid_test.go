@@ -0,0 +1,130 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"uuid", "9b2c3f4e-1d2a-4b5c-8d9e-0f1a2b3c4d5e", true},
+		{"printable", "req-42/a_b.c", true},
+		{"empty", "", false},
+		{"too long", strings.Repeat("a", maxLength+1), false},
+		{"space", "req 42", false},
+		{"control character", "req-42\n", false},
+		{"non ascii", "réq-42", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resolve(tt.incoming)
+			if (got == tt.incoming) != tt.keep {
+				t.Errorf("Resolve(%q) = %q, want it kept: %v", tt.incoming, got, tt.keep)
+			}
+			if got == "" {
+				t.Errorf("Resolve(%q) is empty", tt.incoming)
+			}
+		})
+	}
+}
+
+// headerCarrier is the transport.Header of an http.Header.
+type headerCarrier http.Header
+
+func (h headerCarrier) Get(key string) string      { return http.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// testTransport is the transport of a gRPC call.
+type testTransport struct {
+	request, reply headerCarrier
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "/helloworld.v1.Greeter/SayHello" }
+func (t *testTransport) RequestHeader() transport.Header { return t.request }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.reply }
+
+// entries records the keyvals of every entry logged.
+type entries [][]interface{}
+
+func (e *entries) Log(_ log.Level, keyvals ...interface{}) error {
+	*e = append(*e, keyvals)
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		ctxID    string
+		want     string
+	}{
+		{name: "incoming", incoming: "req-42", want: "req-42"},
+		{name: "generated", want: ""},
+		{name: "invalid incoming", incoming: "req 42", want: ""},
+		{name: "already in the context", incoming: "req-42", ctxID: "req-7", want: "req-7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &testTransport{request: headerCarrier{}, reply: headerCarrier{}}
+			if tt.incoming != "" {
+				tr.request.Set(Header, tt.incoming)
+			}
+			ctx := transport.NewServerContext(context.Background(), tr)
+			if tt.ctxID != "" {
+				ctx = NewContext(ctx, tt.ctxID)
+			}
+
+			var logged entries
+			logger := log.NewHelper(log.With(&logged, "request_id", Valuer()))
+			var handled string
+			_, err := Server()(func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = FromContext(ctx)
+				logger.WithContext(ctx).Info("handled")
+				return nil, nil
+			})(ctx, nil)
+			if err != nil {
+				t.Fatalf("Server() error = %v", err)
+			}
+
+			if handled == "" || (tt.want != "" && handled != tt.want) || (tt.want == "" && handled == tt.incoming) {
+				t.Errorf("request ID = %q, want %q or a new one for %q", handled, tt.want, tt.incoming)
+			}
+			if got := tr.reply.Get(Header); got != handled {
+				t.Errorf("reply %s = %q, want %q", Header, got, handled)
+			}
+			if want := (entries{{"request_id", handled, log.DefaultMessageKey, "handled"}}); !reflect.DeepEqual(logged, want) {
+				t.Errorf("logged %v, want %v", logged, want)
+			}
+		})
+	}
+}
+
+func TestValuer(t *testing.T) {
+	if got := Valuer()(context.Background()); got != "" {
+		t.Errorf("Valuer() without request ID = %v, want empty", got)
+	}
+	if got := Valuer()(NewContext(context.Background(), "req-42")); got != "req-42" {
+		t.Errorf("Valuer() = %v, want req-42", got)
+	}
+}
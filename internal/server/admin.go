@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"sync/atomic"
+
+	adminv1 "github.com/adam-xu-mantle/go-template/api/admin/v1"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminMethodPrefix is the prefix of the full gRPC method names of the admin service.
+var adminMethodPrefix = "/" + adminv1.Admin_ServiceDesc.ServiceName + "/"
+
+// AdminGate decides whether the admin API is served, on the metrics server and on the gRPC server:
+// while metrics.admin is set, to the clients with the basic authentication credentials of the
+// metrics server. Setting metrics.admin on reload takes effect immediately.
+type AdminGate struct {
+	enabled atomic.Bool
+	auth    *conf.Metrics_BasicAuth
+}
+
+// NewAdminGate creates the AdminGate of c.
+func NewAdminGate(c *conf.Metrics, reloader *conf.Reloader) *AdminGate {
+	g := &AdminGate{auth: c.GetBasicAuth()}
+	g.enabled.Store(c.GetAdmin())
+	reloader.Register(func(bc *conf.Bootstrap) error {
+		g.enabled.Store(bc.GetMetrics().GetAdmin())
+		return nil
+	}, "metrics.admin")
+	return g
+}
+
+// unaryInterceptor serves the calls to the admin service as the gate decides: they are unimplemented
+// while the admin API is disabled, and unauthenticated without the credentials in their authorization
+// metadata, e.g. "Basic <base64 of username:password>". The calls to the other services are served.
+func (g *AdminGate) unaryInterceptor(ctx context.Context, req interface{}, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, adminMethodPrefix) {
+		return handler(ctx, req)
+	}
+	if !g.enabled.Load() {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", info.FullMethod)
+	}
+	username, password, ok := grpcBasicAuth(ctx)
+	if !ok || !matchBasicAuth(g.auth, username, password) {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	return handler(ctx, req)
+}
+
+// grpcBasicAuth returns the basic authentication credentials of the authorization metadata of ctx.
+func grpcBasicAuth(ctx context.Context) (username, password string, ok bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", "", false
+	}
+	encoded, ok := strings.CutPrefix(values[0], "Basic ")
+	if !ok {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// matchBasicAuth reports whether username and password are the credentials of c, or c is nil.
+func matchBasicAuth(c *conf.Metrics_BasicAuth, username, password string) bool {
+	if c == nil {
+		return true
+	}
+	// both are compared, so that the time taken does not tell which one is wrong
+	userOK := subtle.ConstantTimeCompare([]byte(username), []byte(c.GetUsername())) == 1
+	passwordOK := subtle.ConstantTimeCompare([]byte(password), []byte(c.GetPassword())) == 1
+	return userOK && passwordOK
+}
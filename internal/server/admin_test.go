@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	adminv1 "github.com/adam-xu-mantle/go-template/api/admin/v1"
+	v1 "github.com/adam-xu-mantle/go-template/api/helloworld/v1"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminGateUnaryInterceptor(t *testing.T) {
+	auth := &conf.Metrics_BasicAuth{Username: "prometheus", Password: "secret"}
+	basic := func(credentials string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	tests := []struct {
+		name          string
+		admin         bool
+		method        string
+		authorization string
+		wantCode      codes.Code
+	}{
+		{name: "disabled", method: adminv1.Admin_SetLogLevel_FullMethodName, authorization: basic("prometheus:secret"), wantCode: codes.Unimplemented},
+		{name: "no credentials", admin: true, method: adminv1.Admin_SetLogLevel_FullMethodName, wantCode: codes.Unauthenticated},
+		{name: "wrong password", admin: true, method: adminv1.Admin_GetLogLevel_FullMethodName, authorization: basic("prometheus:guess"), wantCode: codes.Unauthenticated},
+		{name: "not basic", admin: true, method: adminv1.Admin_GetLogLevel_FullMethodName, authorization: "Bearer secret", wantCode: codes.Unauthenticated},
+		{name: "not base64", admin: true, method: adminv1.Admin_GetLogLevel_FullMethodName, authorization: "Basic !", wantCode: codes.Unauthenticated},
+		{name: "authenticated", admin: true, method: adminv1.Admin_SetLogLevel_FullMethodName, authorization: basic("prometheus:secret"), wantCode: codes.OK},
+		{name: "other service", method: v1.Greeter_SayHello_FullMethodName, wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloader := conf.NewReloader(&conf.Bootstrap{}, &noopReloadMetricer{}, testLogger)
+			gate := NewAdminGate(&conf.Metrics{Admin: tt.admin, BasicAuth: auth}, reloader)
+
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			served := false
+			_, err := gate.unaryInterceptor(ctx, nil, &ggrpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				served = true
+				return nil, nil
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %s, want %s", code, tt.wantCode)
+			}
+			if served != (tt.wantCode == codes.OK) {
+				t.Errorf("served = %v, want %v", served, tt.wantCode == codes.OK)
+			}
+		})
+	}
+}
+
+func TestAdminGateReload(t *testing.T) {
+	c := &conf.Metrics{BasicAuth: &conf.Metrics_BasicAuth{Username: "u", Password: "p"}}
+	reloader := conf.NewReloader(&conf.Bootstrap{Metrics: c}, &noopReloadMetricer{}, testLogger)
+	gate := NewAdminGate(c, reloader)
+
+	for _, admin := range []bool{true, false} {
+		reloader.Reload(&conf.Bootstrap{Metrics: &conf.Metrics{Admin: admin, BasicAuth: c.BasicAuth}})
+		if got := gate.enabled.Load(); got != admin {
+			t.Errorf("enabled after reloading metrics.admin = %v: %v", admin, got)
+		}
+	}
+}
+
+// noopReloadMetricer records nothing.
+type noopReloadMetricer struct{}
+
+func (*noopReloadMetricer) RecordReload(string) {}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Reasons of the errors rendered for requests that match no route.
+const (
+	reasonRouteNotFound    = "ROUTE_NOT_FOUND"
+	reasonMethodNotAllowed = "METHOD_NOT_ALLOWED"
+)
+
+// encodeError writes err as the Kratos HTTP transport does: the HTTP status of the Kratos error,
+// and a body with its code, reason, message and metadata in the codec negotiated from Accept (JSON by default).
+// It is shared by the gin routes and the Kratos HTTP handlers so that every error has the same envelope.
+func encodeError(w http.ResponseWriter, r *http.Request, err error) {
+	khttp.DefaultErrorEncoder(w, r, err)
+}
+
+// renderError renders err on c and aborts the remaining handlers.
+// Errors that are not Kratos errors are rendered as 500 with an empty reason.
+func (s *HTTPServer) renderError(c *gin.Context, err error) {
+	se := errors.FromError(err)
+	if se.Code >= http.StatusInternalServerError {
+		s.logger.WithContext(c.Request.Context()).Errorf("[HTTP] %s %s failed: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	encodeError(c.Writer, c.Request, se)
+	c.Abort()
+}
+
+// errorRenderer renders the last error added to the context with c.Error,
+// unless the handler already wrote a response. Bind errors are rendered as 400: handlers bind with
+// c.ShouldBind and add the error with c.Error(err).SetType(gin.ErrorTypeBind), since c.Bind writes
+// the status before the error can be rendered.
+func (s *HTTPServer) errorRenderer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+		err := last.Err
+		if se := new(errors.Error); last.IsType(gin.ErrorTypeBind) && !errors.As(err, &se) {
+			err = errors.BadRequest("INVALID_ARGUMENT", err.Error())
+		}
+		s.renderError(c, err)
+	}
+}
+
+// recoveryHandler renders panics in gin handlers as the Kratos recovery middleware does.
+func (s *HTTPServer) recoveryHandler(c *gin.Context, recovered interface{}) {
+	s.renderError(c, recovery.ErrUnknownRequest.WithCause(fmt.Errorf("panic: %v", recovered)))
+}
+
+// notFound renders the error of requests that match no route.
+func (s *HTTPServer) notFound(c *gin.Context) {
+	s.renderError(c, errors.NotFound(reasonRouteNotFound, fmt.Sprintf("no route for %s %s", c.Request.Method, c.Request.URL.Path)))
+}
+
+// methodNotAllowed renders the error of requests to a route that does not serve their method.
+func (s *HTTPServer) methodNotAllowed(c *gin.Context) {
+	s.renderError(c, errors.New(http.StatusMethodNotAllowed, reasonMethodNotAllowed, fmt.Sprintf("method %s not allowed for %s", c.Request.Method, c.Request.URL.Path)))
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+func TestHTTPServerErrors(t *testing.T) {
+	srv, _ := newTestHTTPServer(t, &conf.Server{}, &conf.Metrics{})
+	srv.GET("/test/kratos", func(c *gin.Context) {
+		_ = c.Error(errors.Conflict("GREETER_EXISTS", "greeter exists").WithMetadata(map[string]string{"name": "alice"}))
+	})
+	srv.GET("/test/plain", func(c *gin.Context) {
+		_ = c.Error(http.ErrBodyNotAllowed)
+	})
+	srv.POST("/test/bind", func(c *gin.Context) {
+		var in struct {
+			Name string `json:"name" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&in); err != nil {
+			_ = c.Error(err).SetType(gin.ErrorTypeBind)
+		}
+	})
+	srv.GET("/test/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	if err := srv.registerServices([]middleware.Middleware{recovery.Recovery()}, func(s *khttp.Server) {
+		s.Route("/").GET("/test/service/panic", func(ctx khttp.Context) error {
+			h := ctx.Middleware(func(context.Context, interface{}) (interface{}, error) {
+				panic("boom")
+			})
+			_, err := h(ctx, nil)
+			return err
+		})
+	}); err != nil {
+		t.Fatalf("registerServices() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   map[string]interface{}
+	}{
+		{
+			name:       "no route",
+			method:     http.MethodGet,
+			target:     "/nowhere",
+			wantStatus: http.StatusNotFound,
+			wantBody:   map[string]interface{}{"code": 404.0, "reason": "ROUTE_NOT_FOUND", "message": "no route for GET /nowhere", "metadata": map[string]interface{}{}},
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodDelete,
+			target:     "/helloworld/alice",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   map[string]interface{}{"code": 405.0, "reason": "METHOD_NOT_ALLOWED", "message": "method DELETE not allowed for /helloworld/alice", "metadata": map[string]interface{}{}},
+		},
+		{
+			name:       "kratos error",
+			method:     http.MethodGet,
+			target:     "/test/kratos",
+			wantStatus: http.StatusConflict,
+			wantBody:   map[string]interface{}{"code": 409.0, "reason": "GREETER_EXISTS", "message": "greeter exists", "metadata": map[string]interface{}{"name": "alice"}},
+		},
+		{
+			name:       "other error",
+			method:     http.MethodGet,
+			target:     "/test/plain",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   map[string]interface{}{"code": 500.0, "reason": "", "message": http.ErrBodyNotAllowed.Error(), "metadata": map[string]interface{}{}},
+		},
+		{
+			name:       "bind error",
+			method:     http.MethodPost,
+			target:     "/test/bind",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: map[string]interface{}{
+				"code": 400.0, "reason": "INVALID_ARGUMENT", "metadata": map[string]interface{}{},
+				"message": "Key: 'Name' Error:Field validation for 'Name' failed on the 'required' tag",
+			},
+		},
+		{
+			name:       "panic",
+			method:     http.MethodGet,
+			target:     "/test/panic",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   map[string]interface{}{"code": 500.0, "reason": "UNKNOWN", "message": "unknown request error", "metadata": map[string]interface{}{}},
+		},
+		{
+			name:       "panic in a service",
+			method:     http.MethodGet,
+			target:     "/test/service/panic",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   map[string]interface{}{"code": 500.0, "reason": "UNKNOWN", "message": "unknown request error", "metadata": map[string]interface{}{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, srv, tt.method, tt.target, tt.body, http.Header{"Content-Type": {"application/json"}})
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %s is not JSON: %v", rec.Body, err)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package graphql
+
+import (
+	"context"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter presents resolver errors with the same fields as the HTTP error body:
+// the message of the Kratos error, and its code, reason and metadata as extensions.
+// Errors that are not Kratos errors are converted with errors.FromError, and
+// GraphQL errors, such as those of the query limits, are presented unchanged.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	presented := gqlgen.DefaultErrorPresenter(ctx, err)
+	// the errors of the resolvers are wrapped in a GraphQL error with their path
+	var gqlErr *gqlerror.Error
+	for errors.As(err, &gqlErr) {
+		if gqlErr.Err == nil {
+			return presented
+		}
+		err = gqlErr.Err
+	}
+
+	se := errors.FromError(err)
+	presented.Message = se.Message
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]interface{}{}
+	}
+	presented.Extensions["code"] = se.Code
+	presented.Extensions["reason"] = se.Reason
+	if len(se.Metadata) > 0 {
+		presented.Extensions["metadata"] = se.Metadata
+	}
+	return presented
+}
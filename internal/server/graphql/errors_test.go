@@ -0,0 +1,52 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "github.com/adam-xu-mantle/go-template/api/helloworld/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestErrorPresenter(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantMessage    string
+		wantExtensions map[string]interface{}
+	}{
+		{
+			name:           "kratos error",
+			err:            errors.NotFound(v1.ErrorReason_USER_NOT_FOUND.String(), "user not found"),
+			wantMessage:    "user not found",
+			wantExtensions: map[string]interface{}{"code": 404.0, "reason": "USER_NOT_FOUND"},
+		},
+		{
+			name:        "wrapped kratos error with metadata",
+			err:         fmt.Errorf("saving: %w", errors.Conflict("GREETER_EXISTS", "greeter exists").WithMetadata(map[string]string{"name": "alice"})),
+			wantMessage: "greeter exists",
+			wantExtensions: map[string]interface{}{
+				"code": 409.0, "reason": "GREETER_EXISTS", "metadata": map[string]interface{}{"name": "alice"},
+			},
+		},
+		{
+			name:           "other error",
+			err:            fmt.Errorf("connection refused"),
+			wantMessage:    "connection refused",
+			wantExtensions: map[string]interface{}{"code": 500.0, "reason": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := query(t, newTestHandler(nil, greeterRepo{err: tt.err}), request(`{ sayHello(name: "alice") { message } }`))
+			if len(resp.Errors) != 1 {
+				t.Fatalf("errors = %+v, want one", resp.Errors)
+			}
+			if got := resp.Errors[0]; got.Message != tt.wantMessage || !reflect.DeepEqual(got.Extensions, tt.wantExtensions) {
+				t.Errorf("error = %s %v, want %s %v", got.Message, got.Extensions, tt.wantMessage, tt.wantExtensions)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/server/graphql/generated"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Default settings, used when conf.Server.GraphQL leaves them unset.
+const (
+	DefaultPath           = "/graphql"
+	DefaultPlaygroundPath = "/playground"
+
+	defaultComplexityLimit = 200
+	defaultDepthLimit      = 10
+	defaultAPQCacheSize    = 100
+	queryCacheSize         = 1000
+)
+
+// NewHandler creates the production GraphQL handler configured by c.
+func NewHandler(c *conf.Server_GraphQL, greeterService *service.GreeterService) http.Handler {
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: NewResolver(greeterService),
+	}))
+
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](queryCacheSize))
+	srv.SetErrorPresenter(ErrorPresenter)
+
+	if c.GetIntrospection() {
+		srv.Use(extension.Introspection{})
+	}
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](intOrDefault(c.GetApqCacheSize(), defaultAPQCacheSize)),
+	})
+	srv.Use(extension.FixedComplexityLimit(intOrDefault(c.GetComplexityLimit(), defaultComplexityLimit)))
+	srv.Use(DepthLimit{Limit: intOrDefault(c.GetDepthLimit(), defaultDepthLimit)})
+	srv.Use(Tracing{})
+
+	return srv
+}
+
+// NewPlaygroundHandler creates the GraphQL playground for the endpoint at path.
+func NewPlaygroundHandler(path string) http.Handler {
+	return playground.Handler("GraphQL playground", path)
+}
+
+// DepthLimit rejects operations whose selections are nested deeper than Limit.
+// Introspection fields are not counted, so that tooling keeps working when introspection is enabled.
+type DepthLimit struct {
+	Limit int
+}
+
+var _ interface {
+	gqlgen.HandlerExtension
+	gqlgen.OperationContextMutator
+} = DepthLimit{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (d DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (d DepthLimit) Validate(gqlgen.ExecutableSchema) error {
+	if d.Limit <= 0 {
+		return fmt.Errorf("depth limit must be positive, got %d", d.Limit)
+	}
+	return nil
+}
+
+// MutateOperationContext implements graphql.OperationContextMutator.
+func (d DepthLimit) MutateOperationContext(_ context.Context, opCtx *gqlgen.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil {
+		return nil
+	}
+	if depth := selectionDepth(opCtx.Operation.SelectionSet, map[string]bool{}); depth > d.Limit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Limit)
+		errcode.Set(err, "DEPTH_LIMIT_EXCEEDED")
+		return err
+	}
+	return nil
+}
+
+// selectionDepth returns how deep fields are nested in set, following fragments once.
+func selectionDepth(set ast.SelectionSet, visited map[string]bool) int {
+	depth := 0
+	for _, sel := range set {
+		d := 0
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(sel.SelectionSet, visited)
+		case *ast.InlineFragment:
+			d = selectionDepth(sel.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			if sel.Definition == nil || visited[sel.Name] {
+				continue
+			}
+			visited[sel.Name] = true
+			d = selectionDepth(sel.Definition.SelectionSet, visited)
+			delete(visited, sel.Name)
+		}
+		if d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+func intOrDefault(v int32, def int) int {
+	if v <= 0 {
+		return def
+	}
+	return int(v)
+}
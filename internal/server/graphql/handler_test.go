@@ -0,0 +1,227 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// greeterRepo saves every greeter, or fails with err, its other methods are not used by the schema.
+type greeterRepo struct {
+	biz.GreeterRepo
+	err error
+}
+
+func (r greeterRepo) Save(_ context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return g, nil
+}
+
+// noTx runs the usecases without a transaction.
+type noTx struct{}
+
+func (noTx) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// response is the body of a GraphQL response.
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// query posts the GraphQL request body to h and decodes its response.
+func query(t *testing.T, h http.Handler, body string) response {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, DefaultPath, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	var resp response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response %s is not JSON: %v", w.Body, err)
+	}
+	return resp
+}
+
+// newTestHandler returns the handler of c, saving the greeters with repo.
+func newTestHandler(c *conf.Server_GraphQL, repo greeterRepo) http.Handler {
+	logger := log.NewStdLogger(io.Discard)
+	return NewHandler(c, service.NewGreeterService(biz.NewGreeterUsecase(repo, noTx{}, logger)))
+}
+
+// request returns the body of a GraphQL request of q.
+func request(q string) string {
+	b, _ := json.Marshal(map[string]string{"query": q})
+	return string(b)
+}
+
+func TestHandler(t *testing.T) {
+	const fragments = `query { ...Q }
+fragment Q on Query { sayHello(name: "fragment") { ...R } }
+fragment R on HelloReply { message }`
+
+	tests := []struct {
+		name     string
+		c        *conf.Server_GraphQL
+		query    string
+		wantData string
+		wantCode string
+		// wantMessage is the message of an error without code
+		wantMessage string
+	}{
+		{
+			name:     "query",
+			query:    `{ sayHello(name: "gopher") { message } }`,
+			wantData: `{"sayHello":{"message":"Hello gopher"}}`,
+		},
+		{
+			name:     "depth at the limit",
+			c:        &conf.Server_GraphQL{DepthLimit: 2},
+			query:    `{ sayHello(name: "gopher") { message } }`,
+			wantData: `{"sayHello":{"message":"Hello gopher"}}`,
+		},
+		{
+			name:     "depth over the limit",
+			c:        &conf.Server_GraphQL{DepthLimit: 1},
+			query:    `{ sayHello(name: "gopher") { message } }`,
+			wantCode: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			name:     "depth through fragments",
+			c:        &conf.Server_GraphQL{DepthLimit: 1},
+			query:    fragments,
+			wantCode: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			name:     "introspection fields are not counted",
+			c:        &conf.Server_GraphQL{DepthLimit: 1},
+			query:    `{ __typename }`,
+			wantData: `{"__typename":"Query"}`,
+		},
+		{
+			name:     "complexity over the limit",
+			c:        &conf.Server_GraphQL{ComplexityLimit: 1},
+			query:    `{ sayHello(name: "gopher") { message } }`,
+			wantCode: "COMPLEXITY_LIMIT_EXCEEDED",
+		},
+		{
+			name:        "introspection disabled",
+			query:       `{ __schema { queryType { name } } }`,
+			wantMessage: "introspection disabled",
+		},
+		{
+			name:     "introspection enabled",
+			c:        &conf.Server_GraphQL{Introspection: true},
+			query:    `{ __schema { queryType { name } } }`,
+			wantData: `{"__schema":{"queryType":{"name":"Query"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := query(t, newTestHandler(tt.c, greeterRepo{}), request(tt.query))
+			if tt.wantData != "" {
+				if len(resp.Errors) > 0 || string(resp.Data) != tt.wantData {
+					t.Errorf("response = %s %+v, want %s", resp.Data, resp.Errors, tt.wantData)
+				}
+				return
+			}
+			if len(resp.Errors) != 1 {
+				t.Fatalf("errors = %+v, want one", resp.Errors)
+			}
+			if code, _ := resp.Errors[0].Extensions["code"].(string); code != tt.wantCode {
+				t.Errorf("error = %s with code %q, want %q", resp.Errors[0].Message, code, tt.wantCode)
+			}
+			if tt.wantMessage != "" && resp.Errors[0].Message != tt.wantMessage {
+				t.Errorf("error = %s, want %s", resp.Errors[0].Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestHandlerPersistedQueries(t *testing.T) {
+	h := newTestHandler(nil, greeterRepo{})
+	q := `{ sayHello(name: "persisted") { message } }`
+	sum := sha256.Sum256([]byte(q))
+	extensions := map[string]interface{}{
+		"persistedQuery": map[string]interface{}{"version": 1, "sha256Hash": hex.EncodeToString(sum[:])},
+	}
+	persisted := func(withQuery bool) string {
+		body := map[string]interface{}{"extensions": extensions}
+		if withQuery {
+			body["query"] = q
+		}
+		b, _ := json.Marshal(body)
+		return string(b)
+	}
+	const want = `{"sayHello":{"message":"Hello persisted"}}`
+
+	// the hash is not known yet, the client sends the query with it
+	resp := query(t, h, persisted(false))
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("errors of an unknown hash = %+v, want PERSISTED_QUERY_NOT_FOUND", resp.Errors)
+	}
+	if resp := query(t, h, persisted(true)); len(resp.Errors) > 0 || string(resp.Data) != want {
+		t.Fatalf("response with the query = %s %+v, want %s", resp.Data, resp.Errors, want)
+	}
+	if resp := query(t, h, persisted(false)); len(resp.Errors) > 0 || string(resp.Data) != want {
+		t.Errorf("response of the known hash = %s %+v, want %s", resp.Data, resp.Errors, want)
+	}
+}
+
+func TestSelectionDepth(t *testing.T) {
+	field := func(name string, set ...ast.Selection) *ast.Field {
+		return &ast.Field{Name: name, SelectionSet: set}
+	}
+	// a spreads b, which spreads a again
+	a := &ast.FragmentDefinition{Name: "a"}
+	b := &ast.FragmentDefinition{Name: "b"}
+	a.SelectionSet = ast.SelectionSet{field("x", &ast.FragmentSpread{Name: "b", Definition: b})}
+	b.SelectionSet = ast.SelectionSet{field("y", &ast.FragmentSpread{Name: "a", Definition: a})}
+
+	tests := []struct {
+		name string
+		set  ast.SelectionSet
+		want int
+	}{
+		{"empty", nil, 0},
+		{"fields", ast.SelectionSet{field("a"), field("b", field("c", field("d")))}, 3},
+		{"introspection", ast.SelectionSet{field("__schema", field("types", field("name")))}, 0},
+		{"inline fragment", ast.SelectionSet{&ast.InlineFragment{SelectionSet: ast.SelectionSet{field("a", field("b"))}}}, 2},
+		{"undefined fragment", ast.SelectionSet{&ast.FragmentSpread{Name: "missing"}}, 0},
+		{"fragment cycle", ast.SelectionSet{&ast.FragmentSpread{Name: "a", Definition: a}}, 2},
+		{
+			name: "fragment spread twice",
+			set: ast.SelectionSet{
+				&ast.FragmentSpread{Name: "b", Definition: b},
+				field("z", &ast.FragmentSpread{Name: "b", Definition: b}),
+			},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectionDepth(tt.set, map[string]bool{}); got != tt.want {
+				t.Errorf("selectionDepth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
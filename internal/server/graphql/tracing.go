@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer of the GraphQL spans.
+const tracerName = "github.com/adam-xu-mantle/go-template/internal/server/graphql"
+
+// Tracing creates a span for every field resolved by a resolver, as a child of the span of the request.
+// Fields read from their parent object are not traced.
+type Tracing struct{}
+
+var _ interface {
+	gqlgen.HandlerExtension
+	gqlgen.FieldInterceptor
+} = Tracing{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (Tracing) ExtensionName() string {
+	return "Tracing"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (Tracing) Validate(gqlgen.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField implements graphql.FieldInterceptor.
+func (Tracing) InterceptField(ctx context.Context, next gqlgen.Resolver) (interface{}, error) {
+	fc := gqlgen.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("graphql.field.path", fc.Path().String()),
+		attribute.String("graphql.field.name", fc.Field.Name),
+	}
+	if oc := gqlgen.GetOperationContext(ctx); oc != nil && oc.Operation != nil {
+		attrs = append(attrs,
+			attribute.String("graphql.operation.name", oc.OperationName),
+			attribute.String("graphql.operation.type", string(oc.Operation.Operation)),
+		)
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, fc.Object+"."+fc.Field.Name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
@@ -1,27 +1,61 @@
 package server
 
 import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	adminv1 "github.com/adam-xu-mantle/go-template/api/admin/v1"
 	v1 "github.com/adam-xu-mantle/go-template/api/helloworld/v1"
 
 	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
+	"github.com/adam-xu-mantle/go-template/internal/requestid"
 	"github.com/adam-xu-mantle/go-template/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/logging"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// defaultGRPCTimeout is used when conf.Server.GRPC leaves timeout unset.
+const defaultGRPCTimeout = time.Second
+
 // GRPCServer wraps grpc.Server to implement kratos transport interface
 type GRPCServer struct {
 	*grpc.Server
+
+	timeout  atomic.Int64
+	metricer metrics.Metricer
 }
 
-// NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, logger log.Logger) *GRPCServer {
+// NewGRPCServer new a gRPC server. Its request timeout is applied on reload.
+// The admin service is served as gate decides.
+func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, admin *service.AdminService, gate *AdminGate, registry *health.Registry, reloader *conf.Reloader, metricer metrics.Metricer, logger log.Logger) *GRPCServer {
+	s := &GRPCServer{metricer: metricer}
+	s.setTimeout(c.Grpc.GetTimeout())
+
 	var opts = []grpc.ServerOption{
+		// unary calls are bounded by the timeout of the server, which can change while it serves
+		grpc.Timeout(0),
+		grpc.UnaryInterceptor(s.unaryMetrics, s.unaryTimeout, gate.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamMetrics),
 		grpc.Middleware(
 			recovery.Recovery(),
+			tracing.Server(),
+			requestid.Server(),
+			logging.Server(logger),
 		),
+		// the health service is backed by the health registry instead of the kratos default
+		grpc.CustomHealth(),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -29,10 +63,62 @@ func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, logger log.L
 	if c.Grpc.Addr != "" {
 		opts = append(opts, grpc.Address(c.Grpc.Addr))
 	}
-	if c.Grpc.Timeout != nil {
-		opts = append(opts, grpc.Timeout(c.Grpc.Timeout.AsDuration()))
-	}
 	srv := grpc.NewServer(opts...)
 	v1.RegisterGreeterServer(srv, greeter)
-	return &GRPCServer{Server: srv}
+	adminv1.RegisterAdminServer(srv, admin)
+	healthpb.RegisterHealthServer(srv, &healthServer{server: srv, registry: registry})
+	s.Server = srv
+
+	reloader.Register(func(bc *conf.Bootstrap) error {
+		s.setTimeout(bc.GetServer().GetGrpc().GetTimeout())
+		return nil
+	}, "server.grpc.timeout")
+
+	return s
+}
+
+// setTimeout sets the timeout of unary calls to t, or the default when t is unset.
+func (s *GRPCServer) setTimeout(t *durationpb.Duration) {
+	timeout := defaultGRPCTimeout
+	if t != nil {
+		timeout = t.AsDuration()
+	}
+	s.timeout.Store(int64(timeout))
+}
+
+// unaryTimeout bounds unary calls by the current timeout of the server.
+func (s *GRPCServer) unaryTimeout(ctx context.Context, req interface{}, _ *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler) (interface{}, error) {
+	if timeout := time.Duration(s.timeout.Load()); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return handler(ctx, req)
+}
+
+// unaryMetrics records the code and duration of unary calls. Panics are recorded as Internal,
+// the code the recovery middleware replies with.
+func (s *GRPCServer) unaryMetrics(ctx context.Context, req interface{}, info *ggrpc.UnaryServerInfo, handler ggrpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+	code := codes.Internal
+	defer func() {
+		s.metricer.RecordGRPCRequest(ctx, info.FullMethod, code.String(), time.Since(start))
+	}()
+
+	resp, err = handler(ctx, req)
+	code = status.Code(err)
+	return resp, err
+}
+
+// streamMetrics records the code and duration of streaming calls.
+func (s *GRPCServer) streamMetrics(srv interface{}, ss ggrpc.ServerStream, info *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) (err error) {
+	start := time.Now()
+	code := codes.Internal
+	defer func() {
+		s.metricer.RecordGRPCRequest(ss.Context(), info.FullMethod, code.String(), time.Since(start))
+	}()
+
+	err = handler(srv, ss)
+	code = status.Code(err)
+	return err
 }
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/health"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthWatchInterval is how often Watch streams re-run the checks.
+const healthWatchInterval = 5 * time.Second
+
+// registerHealthRoutes sets up the liveness and readiness endpoints
+func (s *HTTPServer) registerHealthRoutes(registry *health.Registry) {
+	// Liveness: the process is up and serving HTTP
+	s.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": health.StatusOK,
+		})
+	})
+
+	// Readiness: every dependency check passes and the server is not shutting down
+	s.GET("/readyz", func(c *gin.Context) {
+		report := registry.Ready(c.Request.Context())
+		code := http.StatusOK
+		if !report.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, report)
+	})
+}
+
+// healthServer implements grpc.health.v1.Health on top of the health registry.
+// The empty service and every registered gRPC service report the readiness of the server,
+// and the name of a check reports that check alone.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	server   *grpc.Server
+	registry *health.Registry
+}
+
+// Check implements grpc.health.v1.Health.
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, ok := s.status(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch implements grpc.health.v1.Health, sending the status whenever it changes.
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, ok := s.status(stream.Context(), req.GetService())
+		if !ok {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-ticker.C:
+		}
+	}
+}
+
+// status returns the serving status of service, and false when service is unknown.
+func (s *healthServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	if _, ok := s.server.GetServiceInfo()[service]; ok || service == "" {
+		if s.registry.Ready(ctx).Ready() {
+			return healthpb.HealthCheckResponse_SERVING, true
+		}
+		return healthpb.HealthCheckResponse_NOT_SERVING, true
+	}
+
+	res, ok := s.registry.ReadyCheck(ctx, service)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	if res.Status == health.StatusOK {
+		return healthpb.HealthCheckResponse_SERVING, true
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING, true
+}
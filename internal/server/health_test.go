@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	v1 "github.com/adam-xu-mantle/go-template/api/helloworld/v1"
+
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPServerHealthRoutes(t *testing.T) {
+	registry := health.NewRegistry()
+	var redisErr error
+	registry.Register("redis", func(context.Context) error { return redisErr })
+	srv := &HTTPServer{Engine: gin.New(), logger: log.NewHelper(testLogger)}
+	srv.registerHealthRoutes(registry)
+
+	tests := []struct {
+		name       string
+		redisErr   error
+		shutdown   bool
+		wantStatus int
+		wantReport health.Status
+	}{
+		{name: "ready", wantStatus: http.StatusOK, wantReport: health.StatusOK},
+		{name: "a check fails", redisErr: errors.New("connection refused"), wantStatus: http.StatusServiceUnavailable, wantReport: health.StatusFail},
+		{name: "the check recovers", wantStatus: http.StatusOK, wantReport: health.StatusOK},
+		{name: "shutting down", shutdown: true, wantStatus: http.StatusServiceUnavailable, wantReport: health.StatusShuttingDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisErr = tt.redisErr
+			if tt.shutdown {
+				registry.Shutdown()
+			}
+
+			rec := serve(t, srv, http.MethodGet, "/readyz", "", nil)
+			var report health.Report
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("/readyz body %s is not JSON: %v", rec.Body, err)
+			}
+			if rec.Code != tt.wantStatus || report.Status != tt.wantReport {
+				t.Errorf("/readyz = %d %s, want %d %s", rec.Code, report.Status, tt.wantStatus, tt.wantReport)
+			}
+			if res := report.Checks["redis"]; res == nil || (res.Error != "") != (tt.redisErr != nil) {
+				t.Errorf("/readyz redis check = %+v, want the error %v", res, tt.redisErr)
+			}
+
+			// the process stays alive while it is not ready
+			if rec := serve(t, srv, http.MethodGet, "/healthz", "", nil); rec.Code != http.StatusOK {
+				t.Errorf("/healthz = %d, want %d", rec.Code, http.StatusOK)
+			}
+		})
+	}
+}
+
+// watchStream records the statuses sent to a Watch stream.
+type watchStream struct {
+	healthpb.Health_WatchServer
+	ctx  context.Context
+	sent []healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *healthpb.HealthCheckResponse) error {
+	s.sent = append(s.sent, resp.GetStatus())
+	return nil
+}
+
+func TestHealthServer(t *testing.T) {
+	registry := health.NewRegistry()
+	var redisErr error
+	registry.Register("database", func(context.Context) error { return nil })
+	registry.Register("redis", func(context.Context) error { return redisErr })
+	gs := grpc.NewServer()
+	v1.RegisterGreeterServer(gs, service.NewGreeterService(nil))
+	hs := &healthServer{server: gs, registry: registry}
+
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	tests := []struct {
+		name     string
+		redisErr error
+		shutdown bool
+		service  string
+		want     healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "server", service: "", want: serving},
+		{name: "grpc service", service: v1.Greeter_ServiceDesc.ServiceName, want: serving},
+		{name: "check", service: "redis", want: serving},
+		{name: "server with a failing check", redisErr: errors.New("connection refused"), service: "", want: notServing},
+		{name: "grpc service with a failing check", redisErr: errors.New("connection refused"), service: v1.Greeter_ServiceDesc.ServiceName, want: notServing},
+		{name: "failing check", redisErr: errors.New("connection refused"), service: "redis", want: notServing},
+		{name: "other check", redisErr: errors.New("connection refused"), service: "database", want: serving},
+		{name: "server shutting down", shutdown: true, service: "", want: notServing},
+		{name: "check shutting down", shutdown: true, service: "database", want: notServing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redisErr = tt.redisErr
+			if tt.shutdown {
+				registry.Shutdown()
+			}
+			resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+			if err != nil {
+				t.Fatalf("Check(%q) error = %v", tt.service, err)
+			}
+			if resp.GetStatus() != tt.want {
+				t.Errorf("Check(%q) = %s, want %s", tt.service, resp.GetStatus(), tt.want)
+			}
+		})
+	}
+
+	if _, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "kafka"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Check(kafka) error = %v, want %s", err, codes.NotFound)
+	}
+
+	// Watch sends the current status, and ends with the stream
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, tt := range []struct {
+		service string
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"", notServing},
+		{"kafka", healthpb.HealthCheckResponse_SERVICE_UNKNOWN},
+	} {
+		stream := &watchStream{ctx: ctx}
+		if err := hs.Watch(&healthpb.HealthCheckRequest{Service: tt.service}, stream); status.Code(err) != codes.Canceled {
+			t.Errorf("Watch(%q) error = %v, want %s", tt.service, err, codes.Canceled)
+		}
+		if len(stream.sent) != 1 || stream.sent[0] != tt.want {
+			t.Errorf("Watch(%q) sent %v, want %s", tt.service, stream.sent, tt.want)
+		}
+	}
+}
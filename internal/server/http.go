@@ -2,20 +2,29 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
-	//"go-template/api/helloworld/graphql/generated"
 	v1 "github.com/adam-xu-mantle/go-template/api/helloworld/v1"
 
 	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/health"
 	"github.com/adam-xu-mantle/go-template/internal/metrics"
+	"github.com/adam-xu-mantle/go-template/internal/requestid"
+	"github.com/adam-xu-mantle/go-template/internal/server/graphql"
 	"github.com/adam-xu-mantle/go-template/internal/service"
+	"github.com/adam-xu-mantle/go-template/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // HTTPServer wraps gin.Engine to implement kratos transport interface
@@ -25,15 +34,15 @@ type HTTPServer struct {
 	logger  *log.Helper
 	network string
 	address string
-	timeout time.Duration
+	timeout atomic.Int64
 }
 
+// defaultHTTPTimeout is used when conf.Server.HTTP leaves timeout unset.
+const defaultHTTPTimeout = 30 * time.Second
+
 // customMiddleware is a middleware that logs the request and response
 func customMiddleware(logger *log.Helper, metricer metrics.Metricer) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		done := metricer.RecordMetricRequests(c.Request.URL.Path, c.Request.Method)
-		defer done()
-
 		// Start timer
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -50,29 +59,53 @@ func customMiddleware(logger *log.Helper, metricer metrics.Metricer) gin.Handler
 		method := c.Request.Method
 		statusCode := c.Writer.Status()
 
+		// the route template rather than the path, so that path parameters do not create series
+		route := c.FullPath()
+		if route == "" {
+			route = metrics.UnmatchedRoute
+		}
+		metricer.RecordHTTPRequest(c.Request.Context(), method, route, statusCode, latency)
+
 		if raw != "" {
 			path = path + "?" + raw
 		}
 
-		logger.Infow("clientIP", clientIP, "method", method, "path", path, "statusCode", statusCode, "latency", latency)
+		logger.WithContext(c.Request.Context()).Infow("clientIP", clientIP, "method", method, "path", path, "statusCode", statusCode, "latency", latency)
 
 	}
 }
 
-// NewHTTPServer creates a new Gin HTTP server.
-func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, logger log.Logger) *HTTPServer {
+// requestTracing creates a span for every request to the service, named after its route, continuing
+// the trace of the W3C trace context headers of the request. Health checks are not traced.
+func requestTracing(service tracing.Service) gin.HandlerFunc {
+	return otelgin.Middleware(service.Name, otelgin.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/healthz" && r.URL.Path != "/readyz"
+	}))
+}
+
+// requestID injects the request ID of the request, from its X-Request-ID header or a new one,
+// into the request context so that every log line of the request carries it, and returns it in the response.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Resolve(c.GetHeader(requestid.Header))
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
+
+// NewHTTPServer creates a new Gin HTTP server. Its request timeout is applied on reload.
+func NewHTTPServer(c *conf.Server, svc tracing.Service, greeter *service.GreeterService, registry *health.Registry, reloader *conf.Reloader, metricer metrics.Metricer, logger log.Logger) (*HTTPServer, error) {
 	gin.SetMode(gin.ReleaseMode)
 
 	r := gin.New()
 
-	metricer := metrics.NewMetricer("", "")
 	logHelper := log.NewHelper(logger)
-	r.Use(customMiddleware(logHelper, metricer), gin.Recovery())
 
 	// Set default values
 	network := "tcp"
 	address := ":8000"
-	timeout := 30 * time.Second
+	timeout := defaultHTTPTimeout
 
 	if c.Http != nil {
 		if c.Http.Network != "" {
@@ -90,9 +123,13 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, logger log.L
 		Engine:  r,
 		network: network,
 		address: address,
-		timeout: timeout,
 		logger:  logHelper,
 	}
+	srv.timeout.Store(int64(timeout))
+	r.Use(requestTracing(svc), requestID(), srv.requestTimeout(), customMiddleware(logHelper, metricer), gin.CustomRecovery(srv.recoveryHandler), srv.errorRenderer())
+	r.NoRoute(srv.notFound)
+	r.HandleMethodNotAllowed = true
+	r.NoMethod(srv.methodNotAllowed)
 
 	srv.server = &http.Server{
 		Addr:         address,
@@ -102,73 +139,102 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, logger log.L
 	}
 
 	// Register routes
-	srv.registerRoutes(greeter)
-
-	return srv
-}
-
-// registerRoutes sets up the API routes
-func (s *HTTPServer) registerRoutes(greeter *service.GreeterService) {
-	// Register the greeter route: GET /helloworld/{name}
-	s.GET("/helloworld/:name", func(c *gin.Context) {
-		name := c.Param("name")
+	srv.registerHealthRoutes(registry)
+	if err := srv.registerRoutes(greeter); err != nil {
+		return nil, err
+	}
+	srv.registerGraphQLRoutes(c.Graphql, greeter)
 
-		req := &v1.HelloRequest{
-			Name: name,
+	reloader.Register(func(bc *conf.Bootstrap) error {
+		timeout := defaultHTTPTimeout
+		if t := bc.GetServer().GetHttp().GetTimeout(); t != nil {
+			timeout = t.AsDuration()
 		}
+		srv.timeout.Store(int64(timeout))
+		return nil
+	}, "server.http.timeout")
 
-		resp, err := greeter.SayHello(c.Request.Context(), req)
-		if err != nil {
-			s.logger.Errorf("Failed to process SayHello request: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+	return srv, nil
+}
+
+// requestTimeout bounds each request by the current timeout of the server: the request context
+// is cancelled and the connection deadlines are set when it expires.
+func (s *HTTPServer) requestTimeout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := time.Duration(s.timeout.Load())
+		if timeout <= 0 {
+			c.Next()
 			return
 		}
 
-		c.JSON(http.StatusOK, resp)
-	})
-
-	// GraphQL setup
-	// resolver := graphql.NewResolver(greeter)
-	// // NewDefaultServer is a demonstration only. Not for prod.
-	// srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{
-	// 	Resolvers: resolver,
-	// }))
-
-	// GraphQL endpoint
-	// s.POST("/graphql", func(c *gin.Context) {
-	// 	srv.ServeHTTP(c.Writer, c.Request)
-	// })
-
-	// Health check endpoint
-	// s.GET("/health", func(c *gin.Context) {
-	// 	c.JSON(http.StatusOK, gin.H{
-	// 		"status": "ok",
-	// 	})
-	// })
+		deadline := time.Now().Add(timeout)
+		rc := http.NewResponseController(c.Writer)
+		_ = rc.SetReadDeadline(deadline)
+		_ = rc.SetWriteDeadline(deadline)
+
+		ctx, cancel := context.WithDeadline(c.Request.Context(), deadline)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
 }
 
-// Start implements the transport.Server interface
-func (s *HTTPServer) Start(ctx context.Context) error {
-	s.logger.Infof("[HTTP] server listening on: %s", s.address)
+// registerRoutes sets up the API routes from the HTTP annotations of the services
+func (s *HTTPServer) registerRoutes(greeter *service.GreeterService) error {
+	return s.registerServices([]middleware.Middleware{recovery.Recovery()},
+		func(srv *khttp.Server) { v1.RegisterGreeterHTTPServer(srv, greeter) },
+	)
+}
+
+// registerGraphQLRoutes sets up the GraphQL endpoint and playground
+func (s *HTTPServer) registerGraphQLRoutes(c *conf.Server_GraphQL, greeter *service.GreeterService) {
+	if c.GetDisable() {
+		return
+	}
+
+	path := graphql.DefaultPath
+	if c.GetPath() != "" {
+		path = c.GetPath()
+	}
+	gql := gin.WrapH(graphql.NewHandler(c, greeter))
+	s.GET(path, gql)
+	s.POST(path, gql)
+	s.OPTIONS(path, gql)
+
+	if c.GetPlayground() {
+		playgroundPath := graphql.DefaultPlaygroundPath
+		if c.GetPlaygroundPath() != "" {
+			playgroundPath = c.GetPlaygroundPath()
+		}
+		s.GET(playgroundPath, gin.WrapH(graphql.NewPlaygroundHandler(path)))
+	}
+}
 
+// Start implements the transport.Server interface. It serves until the server is stopped,
+// and returns the error that stopped it otherwise, which stops the application.
+func (s *HTTPServer) Start(ctx context.Context) error {
 	listener, err := net.Listen(s.network, s.address)
 	if err != nil {
 		return err
 	}
+	s.logger.Infof("[HTTP] server listening on: %s", s.address)
 
-	go func() {
-		_ = s.server.Serve(listener)
-	}()
-
+	if err := s.server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
 	return nil
 }
 
-// Stop implements the transport.Server interface
+// Stop implements the transport.Server interface. It stops accepting connections and waits for the
+// in-flight requests, including GraphQL, to complete until ctx is done, then closes the remaining connections.
 func (s *HTTPServer) Stop(ctx context.Context) error {
 	s.logger.Info("[HTTP] server stopping")
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		s.logger.Warn("[HTTP] server couldn't stop gracefully in time, closing the remaining connections")
+		return s.server.Close()
+	}
+	return err
 }
 
 // Ensure GinServer implements transport.Server interface
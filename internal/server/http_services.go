@@ -0,0 +1,65 @@
+package server
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/middleware"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// pathVarPattern matches the variables of a mux path template, e.g. {name} or {name:.*},
+// and of a google.api.http template, e.g. {name=**}.
+var pathVarPattern = regexp.MustCompile(`\{([^}:=]+)(?:[:=]([^}]+))?\}`)
+
+// HTTPServiceRegistrar registers a service on a Kratos HTTP server,
+// typically by calling its generated Register<Service>HTTPServer function.
+type HTTPServiceRegistrar func(*khttp.Server)
+
+// registerServices mounts services on the gin engine using the routes generated from their
+// google.api.http annotations. Requests are served by a Kratos HTTP handler, which binds the
+// body, path and query parameters and renders Kratos errors with their HTTP status.
+// Requests are bounded by the timeout of the gin server rather than a Kratos one.
+func (s *HTTPServer) registerServices(m []middleware.Middleware, registrars ...HTTPServiceRegistrar) error {
+	srv := khttp.NewServer(
+		khttp.Timeout(0),
+		khttp.Middleware(m...),
+		khttp.ErrorEncoder(encodeError),
+	)
+	for _, register := range registrars {
+		register(srv)
+	}
+
+	return srv.WalkRoute(func(r khttp.RouteInfo) error {
+		path := ginPath(r.Path)
+		s.logger.Debugf("[HTTP] route %s %s", r.Method, path)
+		s.Handle(r.Method, path, gin.WrapH(srv))
+		return nil
+	})
+}
+
+// ginPath converts a mux path template into a gin route path: {name} becomes :name,
+// and a trailing variable matching several segments, such as {name:.*} or {name=**}, becomes *name.
+func ginPath(template string) string {
+	matches := pathVarPattern.FindAllStringSubmatchIndex(template, -1)
+	if len(matches) == 0 {
+		return template
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(template[last:m[0]])
+		name := template[m[2]:m[3]]
+		multiSegment := m[4] >= 0 && (strings.Contains(template[m[4]:m[5]], ".*") || strings.Contains(template[m[4]:m[5]], "**"))
+		if multiSegment && m[1] == len(template) {
+			b.WriteString("*" + name)
+		} else {
+			b.WriteString(":" + name)
+		}
+		last = m[1]
+	}
+	b.WriteString(template[last:])
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+func TestGinPath(t *testing.T) {
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"/helloworld", "/helloworld"},
+		{"/helloworld/{name}", "/helloworld/:name"},
+		{"/v1/{parent}/books/{book}", "/v1/:parent/books/:book"},
+		{"/v1/files/{path:.*}", "/v1/files/*path"},
+		{"/v1/{name:shelves/.*}", "/v1/*name"},
+		{"/v1/files/{path=**}", "/v1/files/*path"},
+		{"/v1/files/{path=*}", "/v1/files/:path"},
+		// only a trailing variable can match several segments in gin
+		{"/v1/{path:.*}/content", "/v1/:path/content"},
+	}
+	for _, tt := range tests {
+		if got := ginPath(tt.template); got != tt.want {
+			t.Errorf("ginPath(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPServerRegisterServices(t *testing.T) {
+	srv, _ := newTestHTTPServer(t, &conf.Server{}, &conf.Metrics{})
+
+	var paths []string
+	record := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if r, ok := khttp.RequestFromServerContext(ctx); ok {
+				paths = append(paths, r.URL.Path)
+			}
+			return handler(ctx, req)
+		}
+	}
+	err := srv.registerServices([]middleware.Middleware{record}, func(s *khttp.Server) {
+		r := s.Route("/")
+		r.GET("/v1/files/{path:.*}", func(ctx khttp.Context) error {
+			var in struct {
+				Path string `json:"path"`
+			}
+			if err := ctx.BindVars(&in); err != nil {
+				return err
+			}
+			h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+				if in.Path == "missing" {
+					return nil, errors.NotFound("FILE_NOT_FOUND", "no file "+in.Path)
+				}
+				return map[string]string{"path": in.Path}, nil
+			})
+			out, err := h(ctx, &in)
+			if err != nil {
+				return err
+			}
+			return ctx.Result(http.StatusOK, out)
+		})
+	})
+	if err != nil {
+		t.Fatalf("registerServices() error = %v", err)
+	}
+
+	tests := []struct {
+		target     string
+		wantStatus int
+		wantBody   map[string]interface{}
+	}{
+		{"/v1/files/docs/readme.md", http.StatusOK, map[string]interface{}{"path": "docs/readme.md"}},
+		{"/v1/files/missing", http.StatusNotFound, map[string]interface{}{"code": float64(404), "reason": "FILE_NOT_FOUND", "message": "no file missing", "metadata": map[string]interface{}{}}},
+	}
+	for _, tt := range tests {
+		rec := serve(t, srv, http.MethodGet, tt.target, "", nil)
+		if rec.Code != tt.wantStatus {
+			t.Errorf("GET %s status = %d, want %d", tt.target, rec.Code, tt.wantStatus)
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("GET %s body %s is not JSON: %v", tt.target, rec.Body, err)
+		}
+		if !reflect.DeepEqual(body, tt.wantBody) {
+			t.Errorf("GET %s body = %v, want %v", tt.target, body, tt.wantBody)
+		}
+	}
+	if len(paths) != len(tests) {
+		t.Errorf("the middleware handled %v, want every request", paths)
+	}
+}
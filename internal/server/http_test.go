@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adam-xu-mantle/go-template/internal/biz"
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/data"
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	"github.com/adam-xu-mantle/go-template/internal/metrics"
+	"github.com/adam-xu-mantle/go-template/internal/requestid"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+	"github.com/adam-xu-mantle/go-template/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// testLogger discards the logs of the tests.
+var testLogger = log.NewStdLogger(io.Discard)
+
+// newTestHTTPServer returns an HTTP server of c, serving the greeter on an in-memory sqlite database
+// and recording its metrics of mc in the returned registry.
+func newTestHTTPServer(t *testing.T, c *conf.Server, mc *conf.Metrics) (*HTTPServer, *prometheus.Registry) {
+	t.Helper()
+	d, cleanup, err := data.NewData(&conf.Data{Database: &conf.Data_Database{
+		Driver: "sqlite",
+		Source: "file::memory:",
+		// every connection to file::memory: opens its own empty database
+		MaxOpenConns: 1,
+	}}, nil, nil, testLogger)
+	if err != nil {
+		t.Fatalf("NewData() error = %v", err)
+	}
+	t.Cleanup(cleanup)
+	if _, err := data.NewMigrator(d, "../../migrations", testLogger).Up(context.Background(), 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	uc := biz.NewGreeterUsecase(data.NewGreeterRepo(d, testLogger), data.NewTransaction(d), testLogger)
+
+	reg := prometheus.NewRegistry()
+	metricer, err := metrics.NewMetricer(mc, reg)
+	if err != nil {
+		t.Fatalf("NewMetricer() error = %v", err)
+	}
+	reloadMetricer, err := metrics.NewReloadMetricer(mc, reg)
+	if err != nil {
+		t.Fatalf("NewReloadMetricer() error = %v", err)
+	}
+	reloader := conf.NewReloader(&conf.Bootstrap{Server: c, Metrics: mc}, reloadMetricer, testLogger)
+
+	srv, err := NewHTTPServer(c, tracing.Service{Name: "go-template"}, service.NewGreeterService(uc), health.NewRegistry(), reloader, metricer, testLogger)
+	if err != nil {
+		t.Fatalf("NewHTTPServer() error = %v", err)
+	}
+	return srv, reg
+}
+
+// serve serves the request of method to target with body through the gin engine of srv.
+func serve(t *testing.T, srv *HTTPServer, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return rec
+}
+
+// recordSpans installs a global tracer provider recording the spans in the returned exporter,
+// and the W3C trace context propagator, until the test ends.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	return exp
+}
+
+func TestHTTPServerTracing(t *testing.T) {
+	// the request middleware takes the global tracer provider when the server is created
+	exp := recordSpans(t)
+	srv, _ := newTestHTTPServer(t, &conf.Server{}, &conf.Metrics{})
+
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	header := http.Header{}
+	otel.GetTextMapPropagator().Inject(trace.ContextWithRemoteSpanContext(context.Background(), remote), propagation.HeaderCarrier(header))
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		// wantChain are the names of the spans of the request, each the parent of the next
+		wantChain []string
+	}{
+		{
+			name:      "rest",
+			method:    http.MethodGet,
+			target:    "/helloworld/alice",
+			wantChain: []string{"/helloworld/:name", "create greeters"},
+		},
+		{
+			name:      "graphql",
+			method:    http.MethodPost,
+			target:    "/graphql",
+			body:      `{"query":"{ sayHello(name: \"alice\") { message } }"}`,
+			wantChain: []string{"/graphql", "Query.sayHello", "create greeters"},
+		},
+		{
+			name:   "health check not traced",
+			method: http.MethodGet,
+			target: "/healthz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp.Reset()
+			h := header.Clone()
+			h.Set("Content-Type", "application/json")
+			if rec := serve(t, srv, tt.method, tt.target, tt.body, h); rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			spans := exp.GetSpans()
+			if len(spans) != len(tt.wantChain) {
+				t.Fatalf("spans = %v, want %v", spanNames(spans), tt.wantChain)
+			}
+			// the request continues the trace of its headers
+			parent := remote
+			for _, name := range tt.wantChain {
+				span := findSpan(t, spans, name)
+				if span.Parent.SpanID() != parent.SpanID() {
+					t.Errorf("parent of %q = %s, want %s", name, span.Parent.SpanID(), parent.SpanID())
+				}
+				if span.SpanContext.TraceID() != remote.TraceID() {
+					t.Errorf("trace of %q = %s, want %s", name, span.SpanContext.TraceID(), remote.TraceID())
+				}
+				parent = span.SpanContext
+			}
+			// the request span names the server after the service
+			if len(tt.wantChain) > 0 {
+				attrs := findSpan(t, spans, tt.wantChain[0]).Attributes
+				if !slices.Contains(attrs, attribute.String("net.host.name", "go-template")) {
+					t.Errorf("attributes of %q = %v, want the service as net.host.name", tt.wantChain[0], attrs)
+				}
+			}
+		})
+	}
+}
+
+// findSpan returns the span named name, failing the test unless there is exactly one.
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	var found []tracetest.SpanStub
+	for _, s := range spans {
+		if s.Name == name {
+			found = append(found, s)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("spans = %v, want one named %q", spanNames(spans), name)
+	}
+	return found[0]
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestHTTPServerRouteLabels(t *testing.T) {
+	const requests = 20
+	srv, reg := newTestHTTPServer(t, &conf.Server{}, &conf.Metrics{Namespace: "test", MaxRoutes: 2})
+
+	// the paths of the requests to a route and of the unmatched requests create no series
+	for range requests {
+		name := strconv.FormatUint(rand.Uint64(), 36)
+		serve(t, srv, http.MethodGet, "/helloworld/"+name, "", nil)
+		serve(t, srv, http.MethodGet, "/"+name, "", nil)
+	}
+	// the second route is the last one recorded, the routes beyond it share the overflow series
+	serve(t, srv, http.MethodPost, "/graphql", `{"query":"{ sayHello(name: \"alice\") { message } }"}`, http.Header{"Content-Type": {"application/json"}})
+	serve(t, srv, http.MethodGet, "/healthz", "", nil)
+	serve(t, srv, http.MethodGet, "/readyz", "", nil)
+
+	want := map[string]float64{
+		"/helloworld/:name":    requests,
+		"/graphql":             1,
+		metrics.UnmatchedRoute: requests,
+		metrics.OverflowRoute:  2,
+	}
+	if got := requestsByRoute(t, reg, "test_http_requests_total"); !maps.Equal(got, want) {
+		t.Errorf("requests by route = %v, want %v", got, want)
+	}
+}
+
+// requestsByRoute returns the number of requests of the counter name of reg by route.
+func requestsByRoute(t *testing.T, reg prometheus.Gatherer, name string) map[string]float64 {
+	t.Helper()
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	got := make(map[string]float64)
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "route" {
+					got[l.GetValue()] += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return got
+}
+
+// entries records the keyvals of every entry logged.
+type entries [][]interface{}
+
+func (e *entries) Log(_ log.Level, keyvals ...interface{}) error {
+	*e = append(*e, keyvals)
+	return nil
+}
+
+func TestHTTPServerRequestID(t *testing.T) {
+	srv, _ := newTestHTTPServer(t, &conf.Server{}, &conf.Metrics{})
+	var logged entries
+	logger := log.NewHelper(log.With(&logged, "request_id", requestid.Valuer()))
+	srv.GET("/test/log", func(c *gin.Context) {
+		logger.WithContext(c.Request.Context()).Info("handled")
+		c.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "incoming", incoming: "req-42", keep: true},
+		{name: "generated"},
+		{name: "invalid incoming", incoming: "req 42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logged = nil
+			header := http.Header{}
+			if tt.incoming != "" {
+				header.Set(requestid.Header, tt.incoming)
+			}
+			id := serve(t, srv, http.MethodGet, "/test/log", "", header).Header().Get(requestid.Header)
+			if id == "" || (id == tt.incoming) != tt.keep {
+				t.Errorf("%s = %q for the incoming %q, want it kept: %v", requestid.Header, id, tt.incoming, tt.keep)
+			}
+			if want := (entries{{"request_id", id, log.DefaultMessageKey, "handled"}}); !reflect.DeepEqual(logged, want) {
+				t.Errorf("logged %v, want %v", logged, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	adminv1 "github.com/adam-xu-mantle/go-template/api/admin/v1"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// defaultMetricsAddr is used when conf.Metrics leaves addr unset.
+const defaultMetricsAddr = ":8080"
+
+// metricsTimeout bounds reading requests and writing responses, long enough for a 30s CPU profile.
+const metricsTimeout = 60 * time.Second
+
+// MetricsServer serves the Prometheus metrics, the health checks and optionally the pprof profiles
+// and the admin API on their own address, to keep them off the public HTTP server.
+// Disabling metrics makes /metrics respond 404, on start or on reload; the server keeps listening
+// for the health checks, which the orchestrator probes on this address.
+type MetricsServer struct {
+	server  *http.Server
+	logger  *log.Helper
+	network string
+	address string
+	tls     *conf.Metrics_TLS
+
+	enabled atomic.Bool
+	pprof   atomic.Bool
+
+	mu        sync.Mutex
+	running   bool
+	listening bool
+	// errc receives the error that stopped serving, and stopped is closed by Stop.
+	errc    chan error
+	stopped chan struct{}
+}
+
+// NewMetricsServer creates a MetricsServer for the metrics of gatherer. The admin API is served as gate decides.
+func NewMetricsServer(c *conf.Metrics, gatherer prometheus.Gatherer, registry *health.Registry, admin *service.AdminService, gate *AdminGate, reloader *conf.Reloader, logger log.Logger) *MetricsServer {
+	network := "tcp"
+	address := defaultMetricsAddr
+	if c.GetAddr() != "" {
+		address = c.GetAddr()
+	}
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+
+	srv := &MetricsServer{
+		logger:  log.NewHelper(logger),
+		network: network,
+		address: address,
+		tls:     c.GetTls(),
+		errc:    make(chan error, 1),
+		stopped: make(chan struct{}),
+	}
+	srv.enabled.Store(!c.GetDisable())
+	srv.pprof.Store(c.GetPprof())
+
+	// the admin API changes the server, so it is served behind the authentication, as gate decides
+	adminHandler := khttp.NewServer(
+		khttp.Timeout(0),
+		khttp.Middleware(recovery.Recovery()),
+		khttp.ErrorEncoder(encodeError),
+	)
+	adminv1.RegisterAdminHTTPServer(adminHandler, admin)
+
+	auth := basicAuth(c.GetBasicAuth())
+	mux := http.NewServeMux()
+	// the exemplars are only exposed in the OpenMetrics format, which Prometheus asks for
+	mux.Handle("/metrics", auth(srv.gate(&srv.enabled, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))))
+	mux.Handle("/debug/pprof/", auth(srv.gate(&srv.pprof, http.HandlerFunc(pprof.Index))))
+	mux.Handle("/debug/pprof/cmdline", auth(srv.gate(&srv.pprof, http.HandlerFunc(pprof.Cmdline))))
+	mux.Handle("/debug/pprof/profile", auth(srv.gate(&srv.pprof, http.HandlerFunc(pprof.Profile))))
+	mux.Handle("/debug/pprof/symbol", auth(srv.gate(&srv.pprof, http.HandlerFunc(pprof.Symbol))))
+	mux.Handle("/debug/pprof/trace", auth(srv.gate(&srv.pprof, http.HandlerFunc(pprof.Trace))))
+	mux.Handle("/admin/", auth(srv.gate(&gate.enabled, adminHandler)))
+	// probes do not authenticate
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]health.Status{"status": health.StatusOK})
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := registry.Ready(r.Context())
+		code := http.StatusOK
+		if !report.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, report)
+	})
+
+	srv.server = &http.Server{
+		Handler:      mux,
+		ReadTimeout:  metricsTimeout,
+		WriteTimeout: metricsTimeout,
+		IdleTimeout:  metricsTimeout,
+	}
+
+	reloader.Register(func(bc *conf.Bootstrap) error {
+		srv.enabled.Store(!bc.GetMetrics().GetDisable())
+		srv.pprof.Store(bc.GetMetrics().GetPprof())
+		return nil
+	}, "metrics.disable", "metrics.pprof")
+
+	return srv
+}
+
+// gate responds 404 while enabled is false.
+func (s *MetricsServer) gate(enabled *atomic.Bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !enabled.Load() {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// basicAuth returns a middleware requiring the credentials of c, or none when c is nil.
+func basicAuth(c *conf.Metrics_BasicAuth) func(http.Handler) http.Handler {
+	if c == nil {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, p, ok := r.BasicAuth()
+			if !ok || !matchBasicAuth(c, u, p) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="metrics", charset="UTF-8"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Start implements the transport.Server interface. It runs until the server is stopped, and returns the error that stopped serving otherwise,
+// which stops the application.
+func (s *MetricsServer) Start(ctx context.Context) error {
+	s.mu.Lock()
+	select {
+	case <-s.stopped:
+		// stopped before it started, e.g. because another server failed to start
+		s.mu.Unlock()
+		return nil
+	default:
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	if !s.enabled.Load() {
+		s.logger.Info("[Metrics] metrics disabled, serving the health checks only")
+	}
+	if err := s.serve(); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-s.errc:
+		return err
+	case <-s.stopped:
+		return nil
+	}
+}
+
+// serve starts listening unless the server is stopped.
+func (s *MetricsServer) serve() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return nil
+	}
+
+	// the certificate is loaded first, so that a bad one fails the start rather than every handshake
+	var tlsConfig *tls.Config
+	if s.tls != nil {
+		cert, err := tls.LoadX509KeyPair(s.tls.GetCertFile(), s.tls.GetKeyFile())
+		if err != nil {
+			return err
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+
+	listener, err := net.Listen(s.network, s.address)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	s.listening = true
+	s.logger.Infof("[Metrics] server listening on: %s", s.address)
+
+	go func() {
+		if err := s.server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			s.errc <- err
+		}
+	}()
+	return nil
+}
+
+// Stop implements the transport.Server interface. It waits for the in-flight requests, e.g. a CPU profile,
+// to complete until ctx is done, then closes the remaining connections.
+func (s *MetricsServer) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	select {
+	case <-s.stopped:
+		s.mu.Unlock()
+		return nil
+	default:
+		close(s.stopped)
+	}
+	s.running = false
+	listening := s.listening
+	s.mu.Unlock()
+	if !listening {
+		return nil
+	}
+	s.logger.Info("[Metrics] server stopping")
+	err := s.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		s.logger.Warn("[Metrics] server couldn't stop gracefully in time, closing the remaining connections")
+		return s.server.Close()
+	}
+	return err
+}
+
+var _ transport.Server = (*MetricsServer)(nil)
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+	"github.com/adam-xu-mantle/go-template/internal/health"
+	ilog "github.com/adam-xu-mantle/go-template/internal/log"
+	"github.com/adam-xu-mantle/go-template/internal/service"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newTestMetricsServer returns a MetricsServer of c and the reloader of its config.
+func newTestMetricsServer(c *conf.Metrics) (*MetricsServer, *conf.Reloader) {
+	reloader := conf.NewReloader(&conf.Bootstrap{Metrics: c}, &noopReloadMetricer{}, testLogger)
+	admin := service.NewAdminService(ilog.NewLevel(conf.LogLevel_INFO), testLogger)
+	srv := NewMetricsServer(c, prometheus.NewRegistry(), health.NewRegistry(), admin, NewAdminGate(c, reloader), reloader, testLogger)
+	return srv, reloader
+}
+
+// get serves a GET of target through the handler of srv, with the basic authentication credentials
+// username:password unless credentials is empty, and returns the status.
+func get(srv *MetricsServer, target string, credentials string) int {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if username, password, ok := strings.Cut(credentials, ":"); ok {
+		req.SetBasicAuth(username, password)
+	}
+	rec := httptest.NewRecorder()
+	srv.server.Handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestMetricsServer(t *testing.T) {
+	auth := &conf.Metrics_BasicAuth{Username: "prometheus", Password: "secret"}
+	const credentials = "prometheus:secret"
+
+	tests := []struct {
+		name        string
+		c           *conf.Metrics
+		target      string
+		credentials string
+		wantStatus  int
+	}{
+		{name: "metrics", c: &conf.Metrics{BasicAuth: auth}, target: "/metrics", credentials: credentials, wantStatus: http.StatusOK},
+		{name: "metrics without credentials", c: &conf.Metrics{BasicAuth: auth}, target: "/metrics", wantStatus: http.StatusUnauthorized},
+		{name: "metrics with a wrong password", c: &conf.Metrics{BasicAuth: auth}, target: "/metrics", credentials: "prometheus:guess", wantStatus: http.StatusUnauthorized},
+		{name: "metrics without basic auth", c: &conf.Metrics{}, target: "/metrics", wantStatus: http.StatusOK},
+		{name: "metrics disabled", c: &conf.Metrics{BasicAuth: auth, Disable: true}, target: "/metrics", credentials: credentials, wantStatus: http.StatusNotFound},
+		{name: "liveness without credentials", c: &conf.Metrics{BasicAuth: auth}, target: "/healthz", wantStatus: http.StatusOK},
+		{name: "readiness without credentials", c: &conf.Metrics{BasicAuth: auth}, target: "/readyz", wantStatus: http.StatusOK},
+		{name: "readiness with metrics disabled", c: &conf.Metrics{BasicAuth: auth, Disable: true}, target: "/readyz", wantStatus: http.StatusOK},
+		{name: "pprof disabled", c: &conf.Metrics{BasicAuth: auth}, target: "/debug/pprof/", credentials: credentials, wantStatus: http.StatusNotFound},
+		{name: "pprof", c: &conf.Metrics{BasicAuth: auth, Pprof: true}, target: "/debug/pprof/", credentials: credentials, wantStatus: http.StatusOK},
+		{name: "pprof without credentials", c: &conf.Metrics{BasicAuth: auth, Pprof: true}, target: "/debug/pprof/", wantStatus: http.StatusUnauthorized},
+		{name: "admin disabled", c: &conf.Metrics{BasicAuth: auth}, target: "/admin/v1/log/level", credentials: credentials, wantStatus: http.StatusNotFound},
+		{name: "admin", c: &conf.Metrics{BasicAuth: auth, Admin: true}, target: "/admin/v1/log/level", credentials: credentials, wantStatus: http.StatusOK},
+		{name: "admin without credentials", c: &conf.Metrics{BasicAuth: auth, Admin: true}, target: "/admin/v1/log/level", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newTestMetricsServer(tt.c)
+			if got := get(srv, tt.target, tt.credentials); got != tt.wantStatus {
+				t.Errorf("GET %s = %d, want %d", tt.target, got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMetricsServerReload(t *testing.T) {
+	auth := &conf.Metrics_BasicAuth{Username: "prometheus", Password: "secret"}
+	srv, reloader := newTestMetricsServer(&conf.Metrics{BasicAuth: auth})
+
+	status := func(enabled bool) int {
+		if enabled {
+			return http.StatusOK
+		}
+		return http.StatusNotFound
+	}
+	for _, c := range []*conf.Metrics{
+		{BasicAuth: auth, Pprof: true, Admin: true},
+		{BasicAuth: auth, Disable: true},
+		{BasicAuth: auth, Pprof: true},
+		{BasicAuth: auth, Admin: true},
+	} {
+		reloader.Reload(&conf.Bootstrap{Metrics: c})
+		for target, want := range map[string]int{
+			"/metrics":            status(!c.Disable),
+			"/debug/pprof/":       status(c.Pprof),
+			"/admin/v1/log/level": status(c.Admin),
+			"/readyz":             http.StatusOK,
+		} {
+			if got := get(srv, target, "prometheus:secret"); got != want {
+				t.Errorf("GET %s after reloading %v = %d, want %d", target, c, got, want)
+			}
+		}
+	}
+}
+
+// unixClient returns an HTTP client connecting to the unix socket at path, without keep-alives.
+func unixClient(path string) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		DisableKeepAlives: true,
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+func TestMetricsServerStop(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "metrics.sock")
+	srv, _ := newTestMetricsServer(&conf.Metrics{Addr: sock, Disable: true, Pprof: true})
+	active := make(chan struct{}, 2)
+	srv.server.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateActive {
+			active <- struct{}{}
+		}
+	}
+	started := make(chan error, 1)
+	go func() { started <- srv.Start(context.Background()) }()
+
+	// the probes are served with metrics disabled
+	client := unixClient(sock)
+	var resp *http.Response
+	var err error
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if resp, err = client.Get("http://metrics/readyz"); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("GET /readyz error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /readyz = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	<-active
+
+	// a CPU profile outlasting the grace timeout is cut short
+	profiled := make(chan error, 1)
+	go func() {
+		resp, err := client.Get("http://metrics/debug/pprof/profile?seconds=10")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		profiled <- err
+	}()
+	<-active
+
+	const graceTimeout = 100 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), graceTimeout)
+	defer cancel()
+	begin := time.Now()
+	if err := srv.Stop(ctx); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed < graceTimeout || elapsed > 5*time.Second {
+		t.Errorf("Stop() took %s, want the grace timeout %s", elapsed, graceTimeout)
+	}
+	if err := <-profiled; err == nil {
+		t.Error("the profile completed, want its connection closed")
+	}
+	if err := <-started; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
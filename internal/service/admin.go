@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/adam-xu-mantle/go-template/api/admin/v1"
+
+	ilog "github.com/adam-xu-mantle/go-template/internal/log"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminService is the admin service.
+type AdminService struct {
+	v1.UnimplementedAdminServer
+
+	level *ilog.Level
+	log   *log.Helper
+}
+
+// NewAdminService new an admin service that changes the log level of the loggers of level.
+func NewAdminService(level *ilog.Level, logger log.Logger) *AdminService {
+	return &AdminService{level: level, log: log.NewHelper(log.With(logger, "module", "admin"))}
+}
+
+// GetLogLevel implements admin.AdminServer.
+func (s *AdminService) GetLogLevel(ctx context.Context, in *v1.GetLogLevelRequest) (*v1.LogLevelReply, error) {
+	return s.logLevelReply(), nil
+}
+
+// SetLogLevel implements admin.AdminServer.
+func (s *AdminService) SetLogLevel(ctx context.Context, in *v1.SetLogLevelRequest) (*v1.LogLevelReply, error) {
+	loggers := "all the loggers"
+	if in.Module != "" {
+		loggers = "the loggers of module " + in.Module
+	}
+
+	if in.Level == "" {
+		s.level.Reset(in.Module)
+		s.log.WithContext(ctx).Warnf("log level of %s reverted to the config", loggers)
+		return s.logLevelReply(), nil
+	}
+
+	level, err := ilog.ParseLevel(in.Level)
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_ARGUMENT", err.Error())
+	}
+	if in.Ttl != nil && in.Ttl.AsDuration() < 0 {
+		return nil, errors.BadRequest("INVALID_ARGUMENT", "ttl must not be negative")
+	}
+	o := s.level.Override(in.Module, level, in.Ttl.AsDuration())
+
+	until := "until it is reverted"
+	if !o.ExpiresAt.IsZero() {
+		until = fmt.Sprintf("for %s", in.Ttl.AsDuration())
+	}
+	s.log.WithContext(ctx).Warnf("log level of %s set to %s %s", loggers, level, until)
+	return s.logLevelReply(), nil
+}
+
+func (s *AdminService) logLevelReply() *v1.LogLevelReply {
+	reply := &v1.LogLevelReply{Level: s.level.Get().String()}
+	for _, o := range s.level.Overrides() {
+		override := &v1.LogLevelReply_Override{Module: o.Module, Level: o.Level.String()}
+		if !o.ExpiresAt.IsZero() {
+			override.ExpiresAt = timestamppb.New(o.ExpiresAt)
+		}
+		reply.Overrides = append(reply.Overrides, override)
+	}
+	return reply
+}
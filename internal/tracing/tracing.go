@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/adam-xu-mantle/go-template/internal/conf"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+)
+
+// defaultExportTimeout is used when conf.Tracing leaves export_timeout unset.
+const defaultExportTimeout = 10 * time.Second
+
+// Service identifies the service in the spans.
+type Service struct {
+	Name    string
+	Version string
+	ID      string
+}
+
+// Enabled reports whether c enables tracing.
+func Enabled(c *conf.Tracing) bool {
+	return c.GetEndpoint() != ""
+}
+
+// NewExporter creates the OTLP gRPC exporter of the spans to the endpoint of c.
+func NewExporter(ctx context.Context, c *conf.Tracing) (sdktrace.SpanExporter, error) {
+	timeout := defaultExportTimeout
+	if c.GetExportTimeout() != nil {
+		timeout = c.GetExportTimeout().AsDuration()
+	}
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(c.GetEndpoint()),
+		otlptracegrpc.WithTimeout(timeout),
+	}
+	if c.GetInsecure() {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// NewTracerProvider creates a tracer provider that samples traces as configured by c
+// and exports their spans to exporter, e.g. an in-memory exporter in tests.
+func NewTracerProvider(c *conf.Tracing, exporter sdktrace.SpanExporter, service Service) *sdktrace.TracerProvider {
+	ratio := 1.0
+	if c.SampleRatio != nil {
+		ratio = c.GetSampleRatio()
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(service.Name),
+			semconv.ServiceVersion(service.Version),
+			semconv.ServiceInstanceID(service.ID),
+		)),
+	)
+}
+
+// Setup installs the tracer provider of c as the global one, with W3C trace context and
+// baggage propagation. It returns a function flushing and stopping the provider.
+// Nothing is installed when c does not enable tracing.
+func Setup(ctx context.Context, c *conf.Tracing, service Service) (shutdown func(context.Context) error, err error) {
+	if !Enabled(c) {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := NewExporter(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	tp := NewTracerProvider(c, exporter, service)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp.Shutdown, nil
+}
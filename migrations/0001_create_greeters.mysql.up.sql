@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS greeters (
+    id          BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    hello       VARCHAR(255) NOT NULL,
+    created_at  DATETIME(3) NOT NULL,
+    updated_at  DATETIME(3) NOT NULL,
+    INDEX idx_greeters_hello (hello)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS greeters (
+    id          BIGSERIAL PRIMARY KEY,
+    hello       VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_greeters_hello ON greeters(hello);
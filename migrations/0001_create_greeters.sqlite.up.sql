@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS greeters (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    hello       VARCHAR(255) NOT NULL,
+    created_at  DATETIME NOT NULL,
+    updated_at  DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_greeters_hello ON greeters(hello);
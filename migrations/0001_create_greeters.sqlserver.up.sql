@@ -0,0 +1,7 @@
+CREATE TABLE greeters (
+    id          BIGINT IDENTITY(1,1) PRIMARY KEY,
+    hello       NVARCHAR(255) NOT NULL,
+    created_at  DATETIMEOFFSET NOT NULL,
+    updated_at  DATETIMEOFFSET NOT NULL
+);
+CREATE INDEX idx_greeters_hello ON greeters(hello);